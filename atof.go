@@ -2,17 +2,17 @@ package null
 
 import (
 	"errors"
-	"fmt"
 	"math"
 	"strconv"
 )
 
-func encodeFloat(f float64, bits int) ([]byte, error) {
+// appendFloat, appends the JSON encoding of float f to dst.
+func appendFloat(dst []byte, f float64, bits int) ([]byte, error) {
 
 	// match behaviour of json.floatEncoder.encode()
 	if math.IsInf(f, 0) || math.IsNaN(f) {
-		panic(errors.New("null: unsupported floating point value: " +
-			strconv.FormatFloat(f, 'g', -1, bits)))
+		return dst, errors.New("null: unsupported floating point value: " +
+			strconv.FormatFloat(f, 'g', -1, bits))
 	}
 
 	// Convert as if by ES6 number to string conversion.
@@ -28,7 +28,7 @@ func encodeFloat(f float64, bits int) ([]byte, error) {
 			fmt = 'e'
 		}
 	}
-	b := strconv.AppendFloat(nil, f, fmt, -1, bits)
+	b := strconv.AppendFloat(dst, f, fmt, -1, bits)
 	if fmt == 'e' {
 		// clean up e-09 to e-9
 		n := len(b)
@@ -43,6 +43,263 @@ func encodeFloat(f float64, bits int) ([]byte, error) {
 	return b, nil
 }
 
+// parseFloat, converts the decimal floating point number s to a float. It
+// behaves like strconv.ParseFloat, but does not allocate for valid decimal
+// numbers and only allocates the returned error for invalid ones.
+//
+// Numbers that cannot be converted exactly by the fast paths, and all special
+// values (Inf, NaN, hexadecimal floats and underscores), are handed off to
+// strconv.
+func parseFloat(s []byte, bitSize int) (float64, error) {
+	mantissa, exp, neg, trunc, n, ok := readFloat(s)
+	if ok && n == len(s) {
+		if bitSize == 32 {
+			if f, ok := parseFloat32(mantissa, exp, neg, trunc); ok {
+				return float64(f), nil
+			}
+		} else {
+			if f, ok := parseFloat64(mantissa, exp, neg, trunc); ok {
+				return f, nil
+			}
+		}
+	} else if !specialFloat(s) {
+		return 0, &strconv.NumError{Func: "ParseFloat", Num: string(s), Err: strconv.ErrSyntax}
+	}
+	return strconv.ParseFloat(string(s), bitSize)
+}
+
+// specialFloat, reports if s may be a float accepted by strconv.ParseFloat
+// that is not read by readFloat: an infinity, NaN, a hexadecimal float or a
+// number with underscores.
+func specialFloat(s []byte) bool {
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if len(s) == 0 {
+		return false
+	}
+	switch s[0] {
+	case 'i', 'I', 'n', 'N':
+		return true
+	case '0':
+		if len(s) > 1 && (s[1] == 'x' || s[1] == 'X') {
+			return true
+		}
+	}
+	for _, c := range s {
+		if c == '_' {
+			return true
+		}
+	}
+	return false
+}
+
+func parseFloat64(mantissa uint64, exp int, neg, trunc bool) (float64, bool) {
+	if !trunc {
+		if f, ok := atof64exact(mantissa, exp, neg); ok {
+			return f, true
+		}
+	}
+	f, ok := eiselLemire64(mantissa, exp, neg)
+	if ok && trunc {
+		// Even if the mantissa was truncated, we may have found the correct
+		// result. Confirm by converting the upper mantissa bound.
+		f1, ok1 := eiselLemire64(mantissa+1, exp, neg)
+		ok = ok1 && f == f1
+	}
+	return f, ok
+}
+
+func parseFloat32(mantissa uint64, exp int, neg, trunc bool) (float32, bool) {
+	if !trunc {
+		if f, ok := atof32exact(mantissa, exp, neg); ok {
+			return f, true
+		}
+	}
+	f, ok := eiselLemire32(mantissa, exp, neg)
+	if ok && trunc {
+		f1, ok1 := eiselLemire32(mantissa+1, exp, neg)
+		ok = ok1 && f == f1
+	}
+	return f, ok
+}
+
+// readFloat, reads a decimal mantissa and exponent from a float string
+// representation in s; the number may be followed by other characters.
+// readFloat reports the number of bytes consumed (n), and whether a valid
+// mantissa and exponent were found (ok).
+func readFloat(s []byte) (mantissa uint64, exp int, neg, trunc bool, n int, ok bool) {
+	const maxMantDigits = 19 // 10^19 fits in uint64
+
+	i := 0
+	if i >= len(s) {
+		return
+	}
+	switch s[i] {
+	case '+':
+		i++
+	case '-':
+		neg = true
+		i++
+	}
+
+	// digits
+	sawdot := false
+	sawdigits := false
+	nd := 0
+	ndMant := 0
+	dp := 0
+loop:
+	for ; i < len(s); i++ {
+		switch c := s[i]; true {
+		case c == '.':
+			if sawdot {
+				break loop
+			}
+			sawdot = true
+			dp = nd
+			continue
+
+		case '0' <= c && c <= '9':
+			sawdigits = true
+			if c == '0' && nd == 0 { // ignore leading zeros
+				dp--
+				continue
+			}
+			nd++
+			if ndMant < maxMantDigits {
+				mantissa *= 10
+				mantissa += uint64(c - '0')
+				ndMant++
+			} else if c != '0' {
+				trunc = true
+			}
+			continue
+		}
+		break
+	}
+	if !sawdigits {
+		return
+	}
+	if !sawdot {
+		dp = nd
+	}
+
+	// optional exponent moves decimal point.
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i >= len(s) {
+			return
+		}
+		esign := 1
+		if s[i] == '+' {
+			i++
+		} else if s[i] == '-' {
+			i++
+			esign = -1
+		}
+		if i >= len(s) || s[i] < '0' || s[i] > '9' {
+			return
+		}
+		e := 0
+		for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+			if e < 10000 {
+				e = e*10 + int(s[i]) - '0'
+			}
+		}
+		dp += e * esign
+	}
+
+	if mantissa != 0 {
+		exp = dp - ndMant
+	}
+	n = i
+	ok = true
+	return
+}
+
+// Exact powers of 10.
+var float64pow10 = []float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+	1e20, 1e21, 1e22,
+}
+var float32pow10 = []float32{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10}
+
+// If possible to convert decimal representation to 64-bit float f exactly,
+// entirely in floating-point math, do so, avoiding the expense of decimalToFloatBits.
+// Three common cases:
+//
+//	value is exact integer
+//	value is exact integer * exact power of ten
+//	value is exact integer / exact power of ten
+//
+// These all produce potentially inexact but correctly rounded answers.
+func atof64exact(mantissa uint64, exp int, neg bool) (f float64, ok bool) {
+	if mantissa>>52 != 0 {
+		return
+	}
+	f = float64(mantissa)
+	if neg {
+		f = -f
+	}
+	switch {
+	case exp == 0:
+		// an integer.
+		return f, true
+	// Exact integers are <= 10^15.
+	// Exact powers of ten are <= 10^22.
+	case exp > 0 && exp <= 15+22: // int * 10^k
+		// If exponent is big but number of digits is not,
+		// can move a few zeros into the integer part.
+		if exp > 22 {
+			f *= float64pow10[exp-22]
+			exp = 22
+		}
+		if f > 1e15 || f < -1e15 {
+			// the exponent was really too large.
+			return
+		}
+		return f * float64pow10[exp], true
+	case exp < 0 && exp >= -22: // int / 10^k
+		return f / float64pow10[-exp], true
+	}
+	return
+}
+
+// If possible to compute mantissa*10^exp to 32-bit float f exactly,
+// entirely in floating-point math, do so, avoiding the machinery above.
+func atof32exact(mantissa uint64, exp int, neg bool) (f float32, ok bool) {
+	if mantissa>>23 != 0 {
+		return
+	}
+	f = float32(mantissa)
+	if neg {
+		f = -f
+	}
+	switch {
+	case exp == 0:
+		return f, true
+	// Exact integers are <= 10^7.
+	// Exact powers of ten are <= 10^10.
+	case exp > 0 && exp <= 7+10: // int * 10^k
+		// If exponent is big but number of digits is not,
+		// can move a few zeros into the integer part.
+		if exp > 10 {
+			f *= float32pow10[exp-10]
+			exp = 10
+		}
+		if f > 1e7 || f < -1e7 {
+			// the exponent was really too large.
+			return
+		}
+		return f * float32pow10[exp], true
+	case exp < 0 && exp >= -10: // int / 10^k
+		return f / float32pow10[-exp], true
+	}
+	return
+}
+
 func convertFloat(value interface{}, bitSize int) (float64, error) {
 	var f float64
 	var err error
@@ -54,9 +311,9 @@ func convertFloat(value interface{}, bitSize int) (float64, error) {
 	case int64:
 		f = float64(v)
 	case string:
-		f, err = strconv.ParseFloat(v, bitSize)
+		return strconv.ParseFloat(v, bitSize)
 	case []byte:
-		f, err = strconv.ParseFloat(string(v), bitSize)
+		return parseFloat(v, bitSize)
 
 	// Accept other numeric types
 	case float32:
@@ -80,33 +337,18 @@ func convertFloat(value interface{}, bitSize int) (float64, error) {
 	case uint64:
 		f = float64(v)
 	default:
-		err = fmt.Errorf("unsupported Scan, storing driver.Value type %T into type float64", value)
-	}
-	if err == nil && bitSize == 32 {
-		if bitSize == 32 {
-			switch {
-			case f > math.MaxFloat32:
-				f = math.MaxFloat32
-				err = strconv.ErrRange
-			case f < math.SmallestNonzeroFloat32:
-				f = math.SmallestNonzeroFloat32
-				err = strconv.ErrRange
-			}
+		if v, ok := baseValue(value); ok {
+			return convertFloat(v, bitSize)
 		}
+		err = ErrUnsupportedType
 	}
-	// TODO: Match sql.convertAssign error message
-	//
-	if err != nil {
-		// Special case for uint conversions
-		if err == strconv.ErrRange {
-			err = &strconv.NumError{
-				Func: "ParseFloat",
-				Num:  strconv.FormatFloat(f, 'g', -1, bitSize),
-				Err:  strconv.ErrRange,
-			}
+	if err == nil && bitSize == 32 {
+		// Numeric values that overflow, or underflow to zero, as a float32.
+		// Text is rounded to float32 by the parser like database/sql.
+		if a := math.Abs(f); (a > math.MaxFloat32 && !math.IsInf(f, 0)) ||
+			(a != 0 && a < math.SmallestNonzeroFloat32) {
+			err = rangeError("ParseFloat", value)
 		}
-		return f, err
 	}
-
-	return f, nil
+	return f, err
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/rand"
+	"reflect"
 	"regexp"
 	"strconv"
 	"testing"
@@ -25,20 +27,29 @@ var convertFloatTests = []convertFloatTest{
 	{float64(math.MaxFloat64), math.MaxFloat64, 64, nil},
 	{float64(math.MaxFloat32), math.MaxFloat32, 32, nil},
 	{float64(math.SmallestNonzeroFloat32), math.SmallestNonzeroFloat32, 32, nil},
+	{0, 0, 32, nil},
+	{-1, -1, 32, nil},
+	{float64(-math.MaxFloat32), -math.MaxFloat32, 32, nil},
 
 	// Error
 	{float64(math.MaxFloat64), math.MaxFloat64, 32, strconv.ErrRange},
 	{float64(math.SmallestNonzeroFloat64), math.SmallestNonzeroFloat64, 32, strconv.ErrRange},
+	{float64(-math.MaxFloat64), -math.MaxFloat64, 32, strconv.ErrRange},
 }
 
 func init() {
 	for _, test := range convertFloatTests {
 		if s, ok := formatNumber(test.in); ok {
+			out, err := test.out, test.err
+			if test.bitSize == 32 && test.in == float64(math.SmallestNonzeroFloat64) {
+				// Like database/sql, text that underflows a float32 is 0.
+				out, err = 0, nil
+			}
 			convertFloatTests = append(convertFloatTests, convertFloatTest{
-				s, test.out, test.bitSize, test.err,
+				s, out, test.bitSize, err,
 			})
 			convertFloatTests = append(convertFloatTests, convertFloatTest{
-				[]byte(s), test.out, test.bitSize, test.err,
+				[]byte(s), out, test.bitSize, err,
 			})
 		}
 	}
@@ -162,3 +173,184 @@ func TestMarshalFloat(t *testing.T) {
 	test(0, 32)
 	test(math.Copysign(0, -1), 32)
 }
+
+var parseFloatTests = []string{
+	"", "+", "-", ".", "e1", "1e", "1e+", "1x", "1.2.3", "1_000", "0x1p-2",
+	"inf", "-Inf", "+INF", "NaN", "nan", "infinity",
+	"0", "-0", "+0", "0.0", "-0.0", "00", "1", "-1", "+1", ".5", "5.", "-.5",
+	"1.5", "123.456", "1e10", "1E10", "1e+10", "1e-10", "1.7976931348623157e308",
+	"1.7976931348623159e308", "1e309", "-1e309", "4.9406564584124654e-324",
+	"2.4703282292062327e-324", "2.4703282292062328e-324", "1e-400",
+	"3.4028234663852886e38", "3.4028235677973366e38", "1.401298464324817e-45",
+	"7.038531e-26", "22.222222222222222", "2.2250738585072011e-308",
+	"2.2250738585072012e-308", "9007199254740993", "9007199254740992.5",
+	"123456789012345678901234567890", "0.000000000000000000000000000001234",
+	"100000000000000016777215", "100000000000000016777216",
+	"4.630813248087435e+307", "1.00000005960464477550", "1.0000000596046448",
+	"1e23", "8.589973e9", "1.1920929e-7", "16777217", "33554434.5",
+	"1_000", "1_0.5", "_1", "1__0", "1e1_0", "0x1_0p0", "0X1P2", "-0x1.8p1", "0x", "0b1",
+	"+infinity", "-nan", "Infx", "i", "+", "1e+", "abcdefghijklmnopqrstuvwxyzabcdefghijklmnop",
+}
+
+func TestParseFloat(t *testing.T) {
+	for _, bitSize := range []int{32, 64} {
+		for _, in := range parseFloatTests {
+			want, wantErr := strconv.ParseFloat(in, bitSize)
+			got, err := parseFloat([]byte(in), bitSize)
+			if !reflect.DeepEqual(err, wantErr) {
+				t.Errorf("parseFloat(%q, %d) error = %v, want %v", in, bitSize, err, wantErr)
+			}
+			if math.Float64bits(got) != math.Float64bits(want) && !(math.IsNaN(got) && math.IsNaN(want)) {
+				t.Errorf("parseFloat(%q, %d) = %v, want %v", in, bitSize, got, want)
+			}
+		}
+	}
+}
+
+func TestParseFloatRandom(t *testing.T) {
+	n := 100000
+	if testing.Short() {
+		n = 5000
+	}
+	r := rand.New(rand.NewSource(1))
+	formats := []byte{'e', 'f', 'g'}
+	for i := 0; i < n; i++ {
+		x := math.Float64frombits(r.Uint64())
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			continue
+		}
+		prec := r.Intn(25) - 1
+		for _, bitSize := range []int{32, 64} {
+			s := strconv.FormatFloat(x, formats[i%len(formats)], prec, 64)
+			want, wantErr := strconv.ParseFloat(s, bitSize)
+			got, err := parseFloat([]byte(s), bitSize)
+			if math.Float64bits(got) != math.Float64bits(want) || (err == nil) != (wantErr == nil) {
+				t.Fatalf("parseFloat(%q, %d) = %v, %v want %v, %v", s, bitSize, got, err, want, wantErr)
+			}
+		}
+	}
+}
+
+func TestParseFloatAllocs(t *testing.T) {
+	tests := []string{"0", "-1", "123.456", "1e-10", "3.4028234663852886e38",
+		"12345678901234567890123", "-0.000000000000000000000000000001234"}
+	for _, in := range tests {
+		b := []byte(in)
+		for _, bitSize := range []int{32, 64} {
+			allocs := testing.AllocsPerRun(100, func() {
+				parseFloat(b, bitSize)
+			})
+			if allocs != 0 {
+				t.Errorf("parseFloat(%q, %d): got %v allocs, want 0", in, bitSize, allocs)
+			}
+		}
+	}
+}
+
+func TestParseFloatErrorAllocs(t *testing.T) {
+	// Only the returned *strconv.NumError and its Num string are allocated.
+	for _, in := range []string{"", "x", "1x", "1.2.3", "-NaN", "abcdefghijklmnopqrstuvwxyzabcdefghijklmnop"} {
+		b := []byte(in)
+		allocs := testing.AllocsPerRun(100, func() {
+			parseFloat(b, 64)
+		})
+		if allocs > 2 {
+			t.Errorf("parseFloat(%q): got %v allocs, want at most 2", in, allocs)
+		}
+	}
+	for _, in := range []string{"inf", "+Inf", "NaN", "0x1p-2", "1_000"} {
+		b := []byte(in)
+		if allocs := testing.AllocsPerRun(100, func() { parseFloat(b, 64) }); allocs != 0 {
+			t.Errorf("parseFloat(%q): got %v allocs, want 0", in, allocs)
+		}
+	}
+}
+
+func BenchmarkParseFloat(b *testing.B) {
+	s := []byte("123.456")
+	for i := 0; i < b.N; i++ {
+		parseFloat(s, 64)
+	}
+}
+
+func BenchmarkParseFloat_Base(b *testing.B) {
+	s := []byte("123.456")
+	for i := 0; i < b.N; i++ {
+		strconv.ParseFloat(string(s), 64)
+	}
+}
+
+func BenchmarkParseFloat_Error(b *testing.B) {
+	s := []byte("123.456x")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parseFloat(s, 64)
+	}
+}
+
+func BenchmarkParseFloat_ErrorBase(b *testing.B) {
+	s := []byte("123.456x")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		strconv.ParseFloat(string(s), 64)
+	}
+}
+
+func BenchmarkParseFloat_Special(b *testing.B) {
+	s := []byte("-Infinity")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parseFloat(s, 64)
+	}
+}
+
+func BenchmarkParseFloatExp(b *testing.B) {
+	s := []byte("-5.09e75")
+	for i := 0; i < b.N; i++ {
+		parseFloat(s, 64)
+	}
+}
+
+func BenchmarkParseFloatExp_Base(b *testing.B) {
+	s := []byte("-5.09e75")
+	for i := 0; i < b.N; i++ {
+		strconv.ParseFloat(string(s), 64)
+	}
+}
+
+func BenchmarkParseFloatBig(b *testing.B) {
+	s := []byte("123456789123456789123456789")
+	for i := 0; i < b.N; i++ {
+		parseFloat(s, 64)
+	}
+}
+
+func BenchmarkParseFloatBig_Base(b *testing.B) {
+	s := []byte("123456789123456789123456789")
+	for i := 0; i < b.N; i++ {
+		strconv.ParseFloat(string(s), 64)
+	}
+}
+
+func BenchmarkParseFloat32(b *testing.B) {
+	s := []byte("3.1415927e10")
+	for i := 0; i < b.N; i++ {
+		parseFloat(s, 32)
+	}
+}
+
+func BenchmarkParseFloat32_Base(b *testing.B) {
+	s := []byte("3.1415927e10")
+	for i := 0; i < b.N; i++ {
+		strconv.ParseFloat(string(s), 32)
+	}
+}
+
+func BenchmarkConvertFloat_Bytes(b *testing.B) {
+	var v interface{} = []byte("123.456")
+	for i := 0; i < b.N; i++ {
+		if _, err := convertFloat(v, 64); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package null
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
@@ -10,12 +11,14 @@ const (
 	maxInt64  = 1<<63 - 1
 )
 
+// parseInt, is like strconv.ParseInt with base 10, but operates on a byte
+// slice and only allocates when returning an error.
 func parseInt(s []byte, bitSize int) (int64, error) {
 	if bitSize == 0 {
 		bitSize = int(strconv.IntSize)
 	}
 	if len(s) == 0 {
-		return 0, &strconv.NumError{"ParseInt", string(s), strconv.ErrSyntax}
+		return 0, &strconv.NumError{Func: "ParseInt", Num: string(s), Err: strconv.ErrSyntax}
 	}
 
 	// Pick off leading sign.
@@ -29,18 +32,16 @@ func parseInt(s []byte, bitSize int) (int64, error) {
 	}
 
 	// Convert unsigned and check range.
-	un, err := parseUint(s, bitSize)
-	if err != nil && err.(*strconv.NumError).Err != strconv.ErrRange {
-		err.(*strconv.NumError).Func = "ParseInt"
-		err.(*strconv.NumError).Num = string(s0)
-		return 0, err
+	un, err := atoui(s, bitSize)
+	if err != nil && err != strconv.ErrRange {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: string(s0), Err: err}
 	}
 	cutoff := uint64(1 << uint(bitSize-1))
 	if !neg && un >= cutoff {
-		return int64(cutoff - 1), &strconv.NumError{"ParseInt", string(s0), strconv.ErrRange}
+		return int64(cutoff - 1), &strconv.NumError{Func: "ParseInt", Num: string(s0), Err: strconv.ErrRange}
 	}
 	if neg && un > cutoff {
-		return -int64(cutoff), &strconv.NumError{"ParseInt", string(s0), strconv.ErrRange}
+		return -int64(cutoff), &strconv.NumError{Func: "ParseInt", Num: string(s0), Err: strconv.ErrRange}
 	}
 	n := int64(un)
 	if neg {
@@ -50,9 +51,22 @@ func parseInt(s []byte, bitSize int) (int64, error) {
 	return n, nil
 }
 
+// parseUint, is like strconv.ParseUint with base 10, but operates on a byte
+// slice and only allocates when returning an error.
 func parseUint(s []byte, bitSize int) (uint64, error) {
+	n, err := atoui(s, bitSize)
+	if err != nil {
+		return n, &strconv.NumError{Func: "ParseUint", Num: string(s), Err: err}
+	}
+	return n, nil
+}
+
+// atoui, converts the unsigned decimal number s. The returned error is either
+// strconv.ErrSyntax or strconv.ErrRange and is left for the caller to wrap,
+// so that failed conversions only allocate one error.
+func atoui(s []byte, bitSize int) (uint64, error) {
 	if len(s) < 1 {
-		return 0, &strconv.NumError{"ParseUint", string(s), strconv.ErrSyntax}
+		return 0, strconv.ErrSyntax
 	}
 	if bitSize == 0 {
 		bitSize = int(strconv.IntSize)
@@ -60,36 +74,26 @@ func parseUint(s []byte, bitSize int) (uint64, error) {
 	maxVal := uint64(1<<uint(bitSize) - 1)
 
 	var n uint64
-	var err error
 	for i := 0; i < len(s); i++ {
 		v := uint64(s[i]) - '0'
 		if v > 9 {
-			n = 0
-			err = strconv.ErrSyntax
-			goto Error
+			return 0, strconv.ErrSyntax
 		}
 		if n >= maxUint64/10+1 {
 			// n*10 overflows
-			n = maxUint64
-			err = strconv.ErrRange
-			goto Error
+			return maxUint64, strconv.ErrRange
 		}
 		n *= 10
 
 		n1 := n + v
 		if n1 < n || n1 > maxVal {
 			// n+v overflows
-			n = maxUint64
-			err = strconv.ErrRange
-			goto Error
+			return maxUint64, strconv.ErrRange
 		}
 		n = n1
 	}
 
 	return n, nil
-
-Error:
-	return n, &strconv.NumError{"ParseUint", string(s), err}
 }
 
 func convertInt(value interface{}, bitSize int) (int64, error) {
@@ -138,27 +142,35 @@ func convertInt(value interface{}, bitSize int) (int64, error) {
 			n = int64(cutoff - 1)
 			err = strconv.ErrRange
 		}
+
+	// Accept integral floats, such as those returned by SQLite for
+	// integer expressions.
+	case float64:
+		n, err = floatToInt(v, value)
+	case float32:
+		n, err = floatToInt(float64(v), value)
 	default:
-		err = fmt.Errorf("unsupported Scan, storing driver.Value type %T into type int64", value)
+		if v, ok := baseValue(value); ok {
+			return convertInt(v, bitSize)
+		}
+		err = ErrUnsupportedType
 	}
 
-	// TODO: Match sql.convertAssign error message
-	//
 	if err != nil {
 		// Special case for uint conversions
 		if err == strconv.ErrRange {
-			err = &strconv.NumError{"ParseInt", strconv.FormatInt(n, 10), strconv.ErrRange}
+			err = rangeError("ParseInt", value)
 		}
 		return n, err
 	}
 
 	if n >= 0 && uint64(n) >= cutoff {
 		n = int64(cutoff - 1)
-		err = &strconv.NumError{"ParseInt", strconv.FormatInt(n, 10), strconv.ErrRange}
+		err = rangeError("ParseInt", value)
 	}
 	if n < 0 && uint64(-n) > cutoff {
 		n = -int64(cutoff)
-		err = &strconv.NumError{"ParseInt", strconv.FormatInt(n, 10), strconv.ErrRange}
+		err = rangeError("ParseInt", value)
 	}
 
 	return n, err
@@ -215,23 +227,54 @@ func convertUint(value interface{}, bitSize int) (uint64, error) {
 			goto ErrOverflow
 		}
 		n = uint64(v)
+
+	// Accept integral floats, see convertInt.
+	case float64:
+		n, err = floatToUint(v, value)
+	case float32:
+		n, err = floatToUint(float64(v), value)
 	default:
-		err = fmt.Errorf("unsupported Scan, storing driver.Value type %T into type int64", value)
+		if v, ok := baseValue(value); ok {
+			return convertUint(v, bitSize)
+		}
+		err = ErrUnsupportedType
 	}
 
-	// TODO: Match sql.convertAssign error message
-	//
-	if err != nil {
-		// Special case for uint conversions
-		if err == strconv.ErrRange {
-			err = &strconv.NumError{"ParseInt", strconv.FormatUint(n, 10), strconv.ErrRange}
-		}
-	} else if n > cutoff {
+	if err == nil && n > cutoff {
 		n = maxUint64
-		err = &strconv.NumError{"ParseInt", strconv.FormatUint(n, 10), strconv.ErrRange}
+		err = rangeError("ParseUint", value)
 	}
 	return n, err
 
 ErrOverflow:
-	return 0, &strconv.NumError{"ParseInt", strconv.FormatUint(n, 10), strconv.ErrRange}
+	return 0, rangeError("ParseUint", value)
+}
+
+// rangeError, returns a *strconv.NumError reporting that the numeric database
+// value is out of range for the function fn.
+func rangeError(fn string, value interface{}) error {
+	return &strconv.NumError{Func: fn, Num: fmt.Sprint(value), Err: strconv.ErrRange}
+}
+
+// floatToInt, converts the float f of database value value to an int64. Like
+// database/sql, floats with a fractional part are a syntax error.
+func floatToInt(f float64, value interface{}) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: fmt.Sprint(value), Err: strconv.ErrSyntax}
+	}
+	if f < -(1<<63) || f >= 1<<63 {
+		return 0, rangeError("ParseInt", value)
+	}
+	return int64(f), nil
+}
+
+// floatToUint, is floatToInt for uint64.
+func floatToUint(f float64, value interface{}) (uint64, error) {
+	if f != math.Trunc(f) {
+		return 0, &strconv.NumError{Func: "ParseUint", Num: fmt.Sprint(value), Err: strconv.ErrSyntax}
+	}
+	if f < 0 || f >= 1<<64 {
+		return 0, rangeError("ParseUint", value)
+	}
+	return uint64(f), nil
 }
@@ -460,6 +460,20 @@ func BenchmarkParseUint64_Base(b *testing.B) {
 	}
 }
 
+func BenchmarkParseInt_Error(b *testing.B) {
+	s := []byte("99999999999999999999")
+	for i := 0; i < b.N; i++ {
+		parseInt(s, 64)
+	}
+}
+
+func BenchmarkParseInt_ErrorBase(b *testing.B) {
+	s := []byte("99999999999999999999")
+	for i := 0; i < b.N; i++ {
+		strconv.ParseInt(string(s), 10, 64)
+	}
+}
+
 // Numeric Conversions
 
 func BenchmarkConvertInt_Int64(b *testing.B) {
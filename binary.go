@@ -0,0 +1,321 @@
+package null
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// The binary encoding of every type starts with a flag byte holding the
+// version of the encoding in the high 4 bits and the flags below in the low
+// 4 bits. NULL values are encoded as the flag byte alone, valid values are
+// followed by a payload:
+//
+//	Int             varint
+//	Uint64          uvarint
+//	Float64         8 byte little-endian IEEE 754 bits
+//	Float32         4 byte little-endian IEEE 754 bits
+//	String          the bytes of the string
+//	Bool            none, the value is stored in binaryTrue
+//	Time            the encoding of time.Time.MarshalBinary
+//
+// binaryTrue is only defined for valid Bools, any other flags are rejected.
+const (
+	binaryVersion = 1
+
+	binaryValid = 1 << 0
+	binaryTrue  = 1 << 1
+)
+
+var (
+	errBinaryLength = errors.New("null: UnmarshalBinary: invalid length")
+	errBinaryFlags  = errors.New("null: UnmarshalBinary: invalid flags")
+)
+
+// appendBinaryFlag, appends the flag byte for a value with the given flags.
+func appendBinaryFlag(dst []byte, flags byte) []byte {
+	return append(dst, binaryVersion<<4|flags)
+}
+
+// readBinaryFlag, reads the flag byte of data and returns its flags and the
+// remaining payload. Valid values may have the flags in extra set as well.
+func readBinaryFlag(data []byte, extra byte) (flags byte, payload []byte, err error) {
+	if len(data) == 0 {
+		return 0, nil, errBinaryLength
+	}
+	if v := data[0] >> 4; v != binaryVersion {
+		return 0, nil, errors.New("null: UnmarshalBinary: unsupported version " + strconv.Itoa(int(v)))
+	}
+	flags = data[0] & 0xf
+	if flags != 0 && (flags&binaryValid == 0 || flags&^(binaryValid|extra) != 0) {
+		return 0, nil, errBinaryFlags
+	}
+	if flags&binaryValid == 0 && len(data) != 1 {
+		return 0, nil, errBinaryLength
+	}
+	return flags, data[1:], nil
+}
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (i Int) AppendBinary(dst []byte) ([]byte, error) {
+	if !i.Valid {
+		return appendBinaryFlag(dst, 0), nil
+	}
+	return binary.AppendVarint(appendBinaryFlag(dst, binaryValid), int64(i.Int)), nil
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (i Int) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, 1+binary.MaxVarintLen64))
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (i *Int) UnmarshalBinary(data []byte) error {
+	flags, data, err := readBinaryFlag(data, 0)
+	if err != nil || flags&binaryValid == 0 {
+		i.Int, i.Valid = 0, false
+		return err
+	}
+	n, size := binary.Varint(data)
+	if size <= 0 || size != len(data) || int64(int(n)) != n {
+		i.Int, i.Valid = 0, false
+		return errBinaryLength
+	}
+	i.Int, i.Valid = int(n), true
+	return nil
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (i Int) GobEncode() ([]byte, error) { return i.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (i *Int) GobDecode(data []byte) error { return i.UnmarshalBinary(data) }
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (u Uint64) AppendBinary(dst []byte) ([]byte, error) {
+	if !u.Valid {
+		return appendBinaryFlag(dst, 0), nil
+	}
+	return binary.AppendUvarint(appendBinaryFlag(dst, binaryValid), u.Uint64), nil
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (u Uint64) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(make([]byte, 0, 1+binary.MaxVarintLen64))
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (u *Uint64) UnmarshalBinary(data []byte) error {
+	flags, data, err := readBinaryFlag(data, 0)
+	if err != nil || flags&binaryValid == 0 {
+		u.Uint64, u.Valid = 0, false
+		return err
+	}
+	n, size := binary.Uvarint(data)
+	if size <= 0 || size != len(data) {
+		u.Uint64, u.Valid = 0, false
+		return errBinaryLength
+	}
+	u.Uint64, u.Valid = n, true
+	return nil
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (u Uint64) GobEncode() ([]byte, error) { return u.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (u *Uint64) GobDecode(data []byte) error { return u.UnmarshalBinary(data) }
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (f Float64) AppendBinary(dst []byte) ([]byte, error) {
+	if !f.Valid {
+		return appendBinaryFlag(dst, 0), nil
+	}
+	dst = appendBinaryFlag(dst, binaryValid)
+	return binary.LittleEndian.AppendUint64(dst, math.Float64bits(f.Float64)), nil
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (f Float64) MarshalBinary() ([]byte, error) {
+	return f.AppendBinary(make([]byte, 0, 9))
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (f *Float64) UnmarshalBinary(data []byte) error {
+	flags, data, err := readBinaryFlag(data, 0)
+	if err != nil || flags&binaryValid == 0 {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+	if len(data) != 8 {
+		f.Float64, f.Valid = 0, false
+		return errBinaryLength
+	}
+	f.Float64, f.Valid = math.Float64frombits(binary.LittleEndian.Uint64(data)), true
+	return nil
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (f Float64) GobEncode() ([]byte, error) { return f.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (f *Float64) GobDecode(data []byte) error { return f.UnmarshalBinary(data) }
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (f Float32) AppendBinary(dst []byte) ([]byte, error) {
+	if !f.Valid {
+		return appendBinaryFlag(dst, 0), nil
+	}
+	dst = appendBinaryFlag(dst, binaryValid)
+	return binary.LittleEndian.AppendUint32(dst, math.Float32bits(f.Float32)), nil
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (f Float32) MarshalBinary() ([]byte, error) {
+	return f.AppendBinary(make([]byte, 0, 5))
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (f *Float32) UnmarshalBinary(data []byte) error {
+	flags, data, err := readBinaryFlag(data, 0)
+	if err != nil || flags&binaryValid == 0 {
+		f.Float32, f.Valid = 0, false
+		return err
+	}
+	if len(data) != 4 {
+		f.Float32, f.Valid = 0, false
+		return errBinaryLength
+	}
+	f.Float32, f.Valid = math.Float32frombits(binary.LittleEndian.Uint32(data)), true
+	return nil
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (f Float32) GobEncode() ([]byte, error) { return f.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (f *Float32) GobDecode(data []byte) error { return f.UnmarshalBinary(data) }
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (s String) AppendBinary(dst []byte) ([]byte, error) {
+	if !s.Valid {
+		return appendBinaryFlag(dst, 0), nil
+	}
+	return append(appendBinaryFlag(dst, binaryValid), s.String...), nil
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (s String) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(make([]byte, 0, 1+len(s.String)))
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (s *String) UnmarshalBinary(data []byte) error {
+	flags, data, err := readBinaryFlag(data, 0)
+	if err != nil || flags&binaryValid == 0 {
+		s.String, s.Valid = "", false
+		return err
+	}
+	s.String, s.Valid = string(data), true
+	return nil
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (s String) GobEncode() ([]byte, error) { return s.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (s *String) GobDecode(data []byte) error { return s.UnmarshalBinary(data) }
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (s UnescapedString) AppendBinary(dst []byte) ([]byte, error) {
+	return String(s).AppendBinary(dst)
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (s UnescapedString) MarshalBinary() ([]byte, error) {
+	return String(s).MarshalBinary()
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (s *UnescapedString) UnmarshalBinary(data []byte) error {
+	return (*String)(s).UnmarshalBinary(data)
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (s UnescapedString) GobEncode() ([]byte, error) { return s.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (s *UnescapedString) GobDecode(data []byte) error { return s.UnmarshalBinary(data) }
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (b Bool) AppendBinary(dst []byte) ([]byte, error) {
+	var flags byte
+	if b.Valid {
+		flags |= binaryValid
+		if b.Bool {
+			flags |= binaryTrue
+		}
+	}
+	return appendBinaryFlag(dst, flags), nil
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (b Bool) MarshalBinary() ([]byte, error) {
+	return b.AppendBinary(make([]byte, 0, 1))
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (b *Bool) UnmarshalBinary(data []byte) error {
+	flags, data, err := readBinaryFlag(data, binaryTrue)
+	if err == nil && len(data) != 0 {
+		err = errBinaryLength
+	}
+	if err != nil || flags&binaryValid == 0 {
+		b.Bool, b.Valid = false, false
+		return err
+	}
+	b.Bool, b.Valid = flags&binaryTrue != 0, true
+	return nil
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (b Bool) GobEncode() ([]byte, error) { return b.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (b *Bool) GobDecode(data []byte) error { return b.UnmarshalBinary(data) }
+
+// AppendBinary, implements the encoding.BinaryAppender interface.
+func (t Time) AppendBinary(dst []byte) ([]byte, error) {
+	if !t.Valid {
+		return appendBinaryFlag(dst, 0), nil
+	}
+	return t.Time.AppendBinary(appendBinaryFlag(dst, binaryValid))
+}
+
+// MarshalBinary, implements the encoding.BinaryMarshaler interface.
+func (t Time) MarshalBinary() ([]byte, error) {
+	return t.AppendBinary(make([]byte, 0, 16))
+}
+
+// UnmarshalBinary, implements the encoding.BinaryUnmarshaler interface.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	flags, data, err := readBinaryFlag(data, 0)
+	if err != nil || flags&binaryValid == 0 {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+	if err := t.Time.UnmarshalBinary(data); err != nil {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+	t.Valid = true
+	return nil
+}
+
+// GobEncode, implements the gob.GobEncoder interface.
+func (t Time) GobEncode() ([]byte, error) { return t.MarshalBinary() }
+
+// GobDecode, implements the gob.GobDecoder interface.
+func (t *Time) GobDecode(data []byte) error { return t.UnmarshalBinary(data) }
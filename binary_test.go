@@ -0,0 +1,153 @@
+package null
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"testing"
+	"time"
+)
+
+var binaryTests = []struct {
+	in  encoding.BinaryMarshaler
+	out []byte
+}{
+	{Int{}, []byte{0x10}},
+	{NewInt(0), []byte{0x11, 0x00}},
+	{NewInt(-1), []byte{0x11, 0x01}},
+	{NewInt(300), []byte{0x11, 0xd8, 0x04}},
+	{NewInt(math.MinInt32), []byte{0x11, 0xff, 0xff, 0xff, 0xff, 0x0f}},
+	{Uint64{}, []byte{0x10}},
+	{NewUint64(0), []byte{0x11, 0x00}},
+	{NewUint64(300), []byte{0x11, 0xac, 0x02}},
+	{NewUint64(math.MaxUint64), []byte{0x11, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	{Float64{}, []byte{0x10}},
+	{NewFloat64(1.5), []byte{0x11, 0, 0, 0, 0, 0, 0, 0xf8, 0x3f}},
+	{Float32{}, []byte{0x10}},
+	{NewFloat32(1.5), []byte{0x11, 0, 0, 0xc0, 0x3f}},
+	{String{}, []byte{0x10}},
+	{NewString(""), []byte{0x11}},
+	{NewString("abc"), []byte{0x11, 'a', 'b', 'c'}},
+	{UnescapedString{}, []byte{0x10}},
+	{NewUnescapedString("<"), []byte{0x11, '<'}},
+	{Bool{}, []byte{0x10}},
+	{NewBool(false), []byte{0x11}},
+	{NewBool(true), []byte{0x13}},
+	{Time{}, []byte{0x10}},
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for _, test := range binaryTests {
+		b, err := test.in.MarshalBinary()
+		if err != nil {
+			t.Errorf("MarshalBinary(%+v): %v", test.in, err)
+			continue
+		}
+		if !bytes.Equal(b, test.out) {
+			t.Errorf("MarshalBinary(%+v) = %#v, want %#v", test.in, b, test.out)
+		}
+		v := newBinaryUnmarshaler(test.in)
+		if err := v.UnmarshalBinary(b); err != nil {
+			t.Errorf("%T: UnmarshalBinary(%#v): %v", test.in, b, err)
+			continue
+		}
+		if !textEqual(v.(encoding.TextUnmarshaler), test.in) {
+			t.Errorf("%T: UnmarshalBinary(%#v) = %+v, want %+v", test.in, b, v, test.in)
+		}
+	}
+}
+
+func TestMarshalBinaryTime(t *testing.T) {
+	in := NewTime(time.Date(2017, 10, 20, 23, 4, 56, 123456789, time.FixedZone("", -7*3600)))
+	b, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v Time
+	if err := v.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Valid || !v.Time.Equal(in.Time) || v.Time.Format(time.RFC3339) != in.Time.Format(time.RFC3339) {
+		t.Errorf("UnmarshalBinary(%#v) = %v, want %v", b, v.Time, in.Time)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		v  encoding.BinaryUnmarshaler
+		in []byte
+	}{
+		{new(Int), nil},
+		{new(Int), []byte{0x21, 0x00}},
+		{new(Int), []byte{0x10, 0x00}},
+		{new(Int), []byte{0x11}},
+		{new(Int), []byte{0x11, 0x00, 0x00}},
+		{new(Int), []byte{0x11, 0x80}},
+		{new(Uint64), []byte{0x11}},
+		{new(Uint64), []byte{0x11, 0x00, 0x00}},
+		{new(Uint64), []byte{0x11, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02}},
+		{new(Float64), []byte{0x11, 0x00}},
+		{new(Float32), []byte{0x11, 0, 0, 0, 0, 0}},
+		{new(String), []byte{0x10, 'a'}},
+		{new(Bool), []byte{0x11, 0x00}},
+		{new(Bool), []byte{0x12}},
+		{new(Bool), []byte{0x15}},
+		{new(Bool), []byte{0x19}},
+		{new(Int), []byte{0x13, 0x00}},
+		{new(Uint64), []byte{0x12}},
+		{new(String), []byte{0x14}},
+		{new(Time), []byte{0x18}},
+		{new(Time), []byte{0x11, 0x00}},
+	}
+	for _, test := range tests {
+		if err := test.v.UnmarshalBinary(test.in); err == nil {
+			t.Errorf("%T: UnmarshalBinary(%#v): expected error", test.v, test.in)
+		}
+	}
+}
+
+func newBinaryUnmarshaler(v interface{}) encoding.BinaryUnmarshaler {
+	return newTextUnmarshaler(v).(encoding.BinaryUnmarshaler)
+}
+
+type gobRecord struct {
+	Int     Int
+	Uint64  Uint64
+	Float64 Float64
+	Float32 Float32
+	String  String
+	Bool    Bool
+	Time    Time
+}
+
+func TestGob(t *testing.T) {
+	for _, in := range []gobRecord{
+		{},
+		{
+			Int:     NewInt(1),
+			Uint64:  NewUint64(math.MaxUint64),
+			Float64: NewFloat64(2),
+			Float32: NewFloat32(3),
+			String:  NewString("4"),
+			Bool:    NewBool(true),
+			Time:    NewTime(time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC)),
+		},
+	} {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatal(err)
+		}
+		var out gobRecord
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if out.Time.Valid != in.Time.Valid || !out.Time.Time.Equal(in.Time.Time) {
+			t.Errorf("gob: Time = %+v, want %+v", out.Time, in.Time)
+		}
+		out.Time = in.Time
+		if out != in {
+			t.Errorf("gob: got %+v, want %+v", out, in)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package null
+
+import (
+	"errors"
+	"time"
+
+	"github.com/charlievieth/null/cbor"
+)
+
+var errCBORTrailing = errors.New("null: UnmarshalCBOR: trailing data")
+
+// AppendCBOR, appends the CBOR encoding of Int i to dst. NULL is encoded as
+// null.
+func (i Int) AppendCBOR(dst []byte) ([]byte, error) {
+	if i.Valid {
+		return cbor.AppendInt(dst, int64(i.Int)), nil
+	}
+	return cbor.AppendNull(dst), nil
+}
+
+// DecodeCBOR, decodes the next CBOR data item of b into Int i and returns the
+// remaining bytes. Both null and undefined decode as NULL.
+func (i *Int) DecodeCBOR(b []byte) ([]byte, error) {
+	if cbor.IsNull(b) {
+		i.Int, i.Valid = 0, false
+		return b[1:], nil
+	}
+	n, rest, err := cbor.ReadInt(b)
+	if err == nil && int64(int(n)) != n {
+		err = cbor.ErrRange
+	}
+	if err != nil {
+		i.Int, i.Valid = 0, false
+		return b, err
+	}
+	i.Int, i.Valid = int(n), true
+	return rest, nil
+}
+
+// MarshalCBOR, marshals Int i into CBOR, see AppendCBOR.
+func (i Int) MarshalCBOR() ([]byte, error) {
+	return i.AppendCBOR(make([]byte, 0, 20))
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into Int i.
+func (i *Int) UnmarshalCBOR(data []byte) error {
+	rest, err := i.DecodeCBOR(data)
+	if err == nil && len(rest) != 0 {
+		i.Int, i.Valid = 0, false
+		return errCBORTrailing
+	}
+	return err
+}
+
+// AppendCBOR, appends the CBOR encoding of Uint64 u to dst. NULL is encoded
+// as null.
+func (u Uint64) AppendCBOR(dst []byte) ([]byte, error) {
+	if u.Valid {
+		return cbor.AppendUint(dst, u.Uint64), nil
+	}
+	return cbor.AppendNull(dst), nil
+}
+
+// DecodeCBOR, decodes the next CBOR data item of b into Uint64 u and returns
+// the remaining bytes. Both null and undefined decode as NULL.
+func (u *Uint64) DecodeCBOR(b []byte) ([]byte, error) {
+	if cbor.IsNull(b) {
+		u.Uint64, u.Valid = 0, false
+		return b[1:], nil
+	}
+	n, rest, err := cbor.ReadUint(b)
+	if err != nil {
+		u.Uint64, u.Valid = 0, false
+		return b, err
+	}
+	u.Uint64, u.Valid = n, true
+	return rest, nil
+}
+
+// MarshalCBOR, marshals Uint64 u into CBOR, see AppendCBOR.
+func (u Uint64) MarshalCBOR() ([]byte, error) {
+	return u.AppendCBOR(make([]byte, 0, 9))
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into Uint64 u.
+func (u *Uint64) UnmarshalCBOR(data []byte) error {
+	rest, err := u.DecodeCBOR(data)
+	if err == nil && len(rest) != 0 {
+		u.Uint64, u.Valid = 0, false
+		return errCBORTrailing
+	}
+	return err
+}
+
+// AppendCBOR, appends the CBOR encoding of Float64 f to dst. NULL is encoded
+// as null.
+func (f Float64) AppendCBOR(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return cbor.AppendFloat64(dst, f.Float64), nil
+	}
+	return cbor.AppendNull(dst), nil
+}
+
+// DecodeCBOR, decodes the next CBOR data item of b into Float64 f and returns
+// the remaining bytes. Both null and undefined decode as NULL. Decimal
+// fractions (tag 4) are accepted.
+func (f *Float64) DecodeCBOR(b []byte) ([]byte, error) {
+	if cbor.IsNull(b) {
+		f.Float64, f.Valid = 0, false
+		return b[1:], nil
+	}
+	v, rest, err := cbor.ReadFloat64(b)
+	if err != nil {
+		f.Float64, f.Valid = 0, false
+		return b, err
+	}
+	f.Float64, f.Valid = v, true
+	return rest, nil
+}
+
+// MarshalCBOR, marshals Float64 f into CBOR, see AppendCBOR.
+func (f Float64) MarshalCBOR() ([]byte, error) {
+	return f.AppendCBOR(make([]byte, 0, 9))
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into Float64 f.
+func (f *Float64) UnmarshalCBOR(data []byte) error {
+	rest, err := f.DecodeCBOR(data)
+	if err == nil && len(rest) != 0 {
+		f.Float64, f.Valid = 0, false
+		return errCBORTrailing
+	}
+	return err
+}
+
+// AppendCBOR, appends the CBOR encoding of Float32 f to dst. NULL is encoded
+// as null.
+func (f Float32) AppendCBOR(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return cbor.AppendFloat32(dst, f.Float32), nil
+	}
+	return cbor.AppendNull(dst), nil
+}
+
+// DecodeCBOR, decodes the next CBOR data item of b into Float32 f and returns
+// the remaining bytes. Both null and undefined decode as NULL. Decimal
+// fractions (tag 4) are accepted.
+func (f *Float32) DecodeCBOR(b []byte) ([]byte, error) {
+	if cbor.IsNull(b) {
+		f.Float32, f.Valid = 0, false
+		return b[1:], nil
+	}
+	v, rest, err := cbor.ReadFloat32(b)
+	if err != nil {
+		f.Float32, f.Valid = 0, false
+		return b, err
+	}
+	f.Float32, f.Valid = v, true
+	return rest, nil
+}
+
+// MarshalCBOR, marshals Float32 f into CBOR, see AppendCBOR.
+func (f Float32) MarshalCBOR() ([]byte, error) {
+	return f.AppendCBOR(make([]byte, 0, 5))
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into Float32 f.
+func (f *Float32) UnmarshalCBOR(data []byte) error {
+	rest, err := f.DecodeCBOR(data)
+	if err == nil && len(rest) != 0 {
+		f.Float32, f.Valid = 0, false
+		return errCBORTrailing
+	}
+	return err
+}
+
+// AppendCBOR, appends the CBOR encoding of String s to dst. NULL is encoded as
+// null.
+func (s String) AppendCBOR(dst []byte) ([]byte, error) {
+	if s.Valid {
+		return cbor.AppendString(dst, s.String), nil
+	}
+	return cbor.AppendNull(dst), nil
+}
+
+// DecodeCBOR, decodes the next CBOR data item of b into String s and returns
+// the remaining bytes. Both null and undefined decode as NULL. Byte strings
+// are accepted.
+func (s *String) DecodeCBOR(b []byte) ([]byte, error) {
+	if cbor.IsNull(b) {
+		s.String, s.Valid = "", false
+		return b[1:], nil
+	}
+	v, rest, err := cbor.ReadString(b)
+	if err != nil {
+		s.String, s.Valid = "", false
+		return b, err
+	}
+	s.String, s.Valid = v, true
+	return rest, nil
+}
+
+// MarshalCBOR, marshals String s into CBOR, see AppendCBOR.
+func (s String) MarshalCBOR() ([]byte, error) {
+	return s.AppendCBOR(make([]byte, 0, len(s.String)+9))
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into String s.
+func (s *String) UnmarshalCBOR(data []byte) error {
+	rest, err := s.DecodeCBOR(data)
+	if err == nil && len(rest) != 0 {
+		s.String, s.Valid = "", false
+		return errCBORTrailing
+	}
+	return err
+}
+
+// AppendCBOR, appends the CBOR encoding of UnescapedString s to dst.
+func (s UnescapedString) AppendCBOR(dst []byte) ([]byte, error) {
+	return String(s).AppendCBOR(dst)
+}
+
+// DecodeCBOR, decodes the next CBOR data item of b into UnescapedString s
+// and returns the remaining bytes.
+func (s *UnescapedString) DecodeCBOR(b []byte) ([]byte, error) {
+	return (*String)(s).DecodeCBOR(b)
+}
+
+// MarshalCBOR, marshals UnescapedString s into CBOR.
+func (s UnescapedString) MarshalCBOR() ([]byte, error) {
+	return String(s).MarshalCBOR()
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into UnescapedString s.
+func (s *UnescapedString) UnmarshalCBOR(data []byte) error {
+	return (*String)(s).UnmarshalCBOR(data)
+}
+
+// AppendCBOR, appends the CBOR encoding of Bool b to dst. NULL is encoded as
+// null.
+func (b Bool) AppendCBOR(dst []byte) ([]byte, error) {
+	if b.Valid {
+		return cbor.AppendBool(dst, b.Bool), nil
+	}
+	return cbor.AppendNull(dst), nil
+}
+
+// DecodeCBOR, decodes the next CBOR data item of data into Bool b and returns
+// the remaining bytes. Both null and undefined decode as NULL.
+func (b *Bool) DecodeCBOR(data []byte) ([]byte, error) {
+	if cbor.IsNull(data) {
+		b.Bool, b.Valid = false, false
+		return data[1:], nil
+	}
+	v, rest, err := cbor.ReadBool(data)
+	if err != nil {
+		b.Bool, b.Valid = false, false
+		return data, err
+	}
+	b.Bool, b.Valid = v, true
+	return rest, nil
+}
+
+// MarshalCBOR, marshals Bool b into CBOR, see AppendCBOR.
+func (b Bool) MarshalCBOR() ([]byte, error) {
+	return b.AppendCBOR(make([]byte, 0, 1))
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into Bool b.
+func (b *Bool) UnmarshalCBOR(data []byte) error {
+	rest, err := b.DecodeCBOR(data)
+	if err == nil && len(rest) != 0 {
+		b.Bool, b.Valid = false, false
+		return errCBORTrailing
+	}
+	return err
+}
+
+// AppendCBOR, appends the CBOR encoding of Time t to dst. NULL is encoded as
+// null.  Times are encoded as integer seconds since the epoch (tag 1) if they
+// have no fractional second, otherwise as RFC 3339 strings (tag 0).
+func (t Time) AppendCBOR(dst []byte) ([]byte, error) {
+	if t.Valid {
+		return cbor.AppendTime(dst, t.Time), nil
+	}
+	return cbor.AppendNull(dst), nil
+}
+
+// DecodeCBOR, decodes the next CBOR data item of b into Time t and returns the
+// remaining bytes. Both null and undefined decode as NULL. Both RFC 3339 (tag
+// 0) and epoch (tag 1) times are accepted.
+func (t *Time) DecodeCBOR(b []byte) ([]byte, error) {
+	if cbor.IsNull(b) {
+		t.Time, t.Valid = time.Time{}, false
+		return b[1:], nil
+	}
+	v, rest, err := cbor.ReadTime(b)
+	if err != nil {
+		t.Time, t.Valid = time.Time{}, false
+		return b, err
+	}
+	t.Time, t.Valid = v, true
+	return rest, nil
+}
+
+// MarshalCBOR, marshals Time t into CBOR, see AppendCBOR.
+func (t Time) MarshalCBOR() ([]byte, error) {
+	return t.AppendCBOR(make([]byte, 0, 48))
+}
+
+// UnmarshalCBOR, unmarshals the CBOR data item data into Time t.
+func (t *Time) UnmarshalCBOR(data []byte) error {
+	rest, err := t.DecodeCBOR(data)
+	if err == nil && len(rest) != 0 {
+		t.Time, t.Valid = time.Time{}, false
+		return errCBORTrailing
+	}
+	return err
+}
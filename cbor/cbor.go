@@ -0,0 +1,444 @@
+// Package cbor implements a minimal, dependency-free CBOR (RFC 8949) codec
+// for the value types of package null.
+//
+// Values are appended to and read from byte slices. Every Read function
+// returns the decoded value and the remaining bytes of b following it. Only
+// definite length strings are supported.
+package cbor
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Major types.
+const (
+	MajorUint   = 0
+	MajorNegInt = 1
+	MajorBytes  = 2
+	MajorString = 3
+	MajorArray  = 4
+	MajorMap    = 5
+	MajorTag    = 6
+	MajorSimple = 7
+)
+
+// Simple values and tags.
+const (
+	False     = 0xf4
+	True      = 0xf5
+	Null      = 0xf6
+	Undefined = 0xf7
+
+	TagDateTime        = 0 // RFC 3339 date/time string
+	TagEpochDateTime   = 1 // seconds since the Unix epoch, int or float
+	TagDecimalFraction = 4 // [exponent, mantissa], mantissa * 10**exponent
+)
+
+// ErrShortBytes, is returned when b is too short to hold the value read.
+var ErrShortBytes = errors.New("cbor: too few bytes")
+
+// ErrRange, is returned when a value is out of range for the type read.
+var ErrRange = errors.New("cbor: value out of range")
+
+// A TypeError is returned when the next value is not of the type read.
+type TypeError struct {
+	Type    string // the type read
+	Initial byte   // the initial byte of the value
+}
+
+func (e *TypeError) Error() string {
+	return "cbor: cannot read major type " + strconv.Itoa(int(e.Initial>>5)) +
+		" (initial byte 0x" + strconv.FormatUint(uint64(e.Initial), 16) + ") as " + e.Type
+}
+
+// appendHead, appends the head of a data item of major type major and
+// argument n to dst, using the shortest encoding of n.
+func appendHead(dst []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(dst, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(dst, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(dst, major|27), n)
+}
+
+// readHead, reads the head of the next data item of b and returns its major
+// type, additional information and argument.
+func readHead(b []byte) (major, info byte, n uint64, rest []byte, err error) {
+	if len(b) == 0 {
+		return 0, 0, 0, b, ErrShortBytes
+	}
+	major, info = b[0]>>5, b[0]&0x1f
+	size := 0
+	switch {
+	case info < 24:
+		return major, info, uint64(info), b[1:], nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, 0, b, &TypeError{Type: "definite length item", Initial: b[0]}
+	}
+	if len(b) < 1+size {
+		return 0, 0, 0, b, ErrShortBytes
+	}
+	switch size {
+	case 1:
+		n = uint64(b[1])
+	case 2:
+		n = uint64(binary.BigEndian.Uint16(b[1:]))
+	case 4:
+		n = uint64(binary.BigEndian.Uint32(b[1:]))
+	case 8:
+		n = binary.BigEndian.Uint64(b[1:])
+	}
+	return major, info, n, b[1+size:], nil
+}
+
+// AppendNull, appends a null value to dst.
+func AppendNull(dst []byte) []byte {
+	return append(dst, Null)
+}
+
+// AppendBool, appends the bool v to dst.
+func AppendBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, True)
+	}
+	return append(dst, False)
+}
+
+// AppendInt, appends the int v to dst.
+func AppendInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		return appendHead(dst, MajorNegInt, uint64(-1-v))
+	}
+	return appendHead(dst, MajorUint, uint64(v))
+}
+
+// AppendUint, appends the uint v to dst.
+func AppendUint(dst []byte, v uint64) []byte {
+	return appendHead(dst, MajorUint, v)
+}
+
+// AppendFloat64, appends the float64 v to dst. The value is encoded as a
+// single-precision float if that is exact.
+func AppendFloat64(dst []byte, v float64) []byte {
+	if f := float32(v); float64(f) == v || math.IsNaN(v) {
+		return AppendFloat32(dst, f)
+	}
+	return binary.BigEndian.AppendUint64(append(dst, MajorSimple<<5|27), math.Float64bits(v))
+}
+
+// AppendFloat32, appends the float32 v to dst.
+func AppendFloat32(dst []byte, v float32) []byte {
+	return binary.BigEndian.AppendUint32(append(dst, MajorSimple<<5|26), math.Float32bits(v))
+}
+
+// AppendString, appends the UTF-8 text string v to dst.
+func AppendString(dst []byte, v string) []byte {
+	return append(appendHead(dst, MajorString, uint64(len(v))), v...)
+}
+
+// AppendBytes, appends the byte string v to dst.
+func AppendBytes(dst []byte, v []byte) []byte {
+	return append(appendHead(dst, MajorBytes, uint64(len(v))), v...)
+}
+
+// AppendTag, appends the head of tag number tag to dst, it must be followed
+// by the tagged data item.
+func AppendTag(dst []byte, tag uint64) []byte {
+	return appendHead(dst, MajorTag, tag)
+}
+
+// AppendTime, appends the time v to dst. Times without a fractional second
+// are encoded as integer seconds since the epoch (tag 1), otherwise they are
+// encoded as RFC 3339 strings (tag 0), which preserves nanosecond precision
+// and, unlike tag 1, the time zone offset. RFC 3339 is limited to the years
+// 0 to 9999, times with a fractional second outside of them are encoded as
+// floating-point seconds since the epoch (tag 1), which may lose precision.
+func AppendTime(dst []byte, v time.Time) []byte {
+	if v.Nanosecond() == 0 {
+		return AppendInt(AppendTag(dst, TagEpochDateTime), v.Unix())
+	}
+	if y := v.Year(); y < 0 || y > 9999 {
+		sec := float64(v.Unix()) + float64(v.Nanosecond())/1e9
+		return AppendFloat64(AppendTag(dst, TagEpochDateTime), sec)
+	}
+	var a [len(time.RFC3339Nano)]byte
+	return AppendString(AppendTag(dst, TagDateTime), string(v.AppendFormat(a[:0], time.RFC3339Nano)))
+}
+
+// AppendDecimal, appends the decimal fraction mantissa * 10**exp to dst
+// (tag 4).
+func AppendDecimal(dst []byte, exp, mantissa int64) []byte {
+	dst = appendHead(AppendTag(dst, TagDecimalFraction), MajorArray, 2)
+	return AppendInt(AppendInt(dst, exp), mantissa)
+}
+
+// IsNull, reports whether the next value of b is null or undefined.
+func IsNull(b []byte) bool {
+	return len(b) > 0 && (b[0] == Null || b[0] == Undefined)
+}
+
+// ReadNull, reads a null or undefined value from b.
+func ReadNull(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, ErrShortBytes
+	}
+	if !IsNull(b) {
+		return b, &TypeError{Type: "null", Initial: b[0]}
+	}
+	return b[1:], nil
+}
+
+// ReadBool, reads a bool from b.
+func ReadBool(b []byte) (bool, []byte, error) {
+	if len(b) == 0 {
+		return false, b, ErrShortBytes
+	}
+	switch b[0] {
+	case True:
+		return true, b[1:], nil
+	case False:
+		return false, b[1:], nil
+	}
+	return false, b, &TypeError{Type: "bool", Initial: b[0]}
+}
+
+// ReadTag, reads the head of a tag from b and returns the tag number, the
+// tagged data item follows in the remaining bytes.
+func ReadTag(b []byte) (uint64, []byte, error) {
+	major, _, n, rest, err := readHead(b)
+	if err != nil {
+		return 0, b, err
+	}
+	if major != MajorTag {
+		return 0, b, &TypeError{Type: "tag", Initial: b[0]}
+	}
+	return n, rest, nil
+}
+
+// ReadInt, reads an int from b.
+func ReadInt(b []byte) (int64, []byte, error) {
+	major, _, n, rest, err := readHead(b)
+	if err != nil {
+		return 0, b, err
+	}
+	switch major {
+	case MajorUint:
+		if n > math.MaxInt64 {
+			return 0, b, ErrRange
+		}
+		return int64(n), rest, nil
+	case MajorNegInt:
+		if n > math.MaxInt64 {
+			return 0, b, ErrRange
+		}
+		return -1 - int64(n), rest, nil
+	}
+	return 0, b, &TypeError{Type: "int", Initial: b[0]}
+}
+
+// ReadUint, reads an unsigned int from b.
+func ReadUint(b []byte) (uint64, []byte, error) {
+	major, _, n, rest, err := readHead(b)
+	if err != nil {
+		return 0, b, err
+	}
+	switch major {
+	case MajorUint:
+		return n, rest, nil
+	case MajorNegInt:
+		return 0, b, ErrRange
+	}
+	return 0, b, &TypeError{Type: "uint", Initial: b[0]}
+}
+
+// ReadFloat64, reads a float from b. Half, single and double-precision
+// floats, ints and decimal fractions (tag 4) are accepted.
+func ReadFloat64(b []byte) (float64, []byte, error) {
+	major, info, n, rest, err := readHead(b)
+	if err != nil {
+		return 0, b, err
+	}
+	switch major {
+	case MajorUint:
+		return float64(n), rest, nil
+	case MajorNegInt:
+		return -1 - float64(n), rest, nil
+	case MajorTag:
+		if n == TagDecimalFraction {
+			return readDecimal(b, rest)
+		}
+	case MajorSimple:
+		switch info {
+		case 25:
+			return float16to64(uint16(n)), rest, nil
+		case 26:
+			return float64(math.Float32frombits(uint32(n))), rest, nil
+		case 27:
+			return math.Float64frombits(n), rest, nil
+		}
+	}
+	return 0, b, &TypeError{Type: "float", Initial: b[0]}
+}
+
+// readDecimal, reads the [exponent, mantissa] array of a decimal fraction
+// from rest, b is the start of the tagged item.
+func readDecimal(b, rest []byte) (float64, []byte, error) {
+	major, _, n, rest, err := readHead(rest)
+	if err != nil {
+		return 0, b, err
+	}
+	if major != MajorArray || n != 2 {
+		return 0, b, &TypeError{Type: "decimal fraction", Initial: b[0]}
+	}
+	exp, rest, err := ReadInt(rest)
+	if err != nil {
+		return 0, b, err
+	}
+	mant, rest, err := ReadInt(rest)
+	if err != nil {
+		return 0, b, err
+	}
+	// Parse the decimal as text to get a correctly rounded result.
+	s := strconv.AppendInt(make([]byte, 0, 48), mant, 10)
+	s = append(s, 'e')
+	s = strconv.AppendInt(s, exp, 10)
+	f, err := strconv.ParseFloat(string(s), 64)
+	if err != nil {
+		return 0, b, ErrRange
+	}
+	return f, rest, nil
+}
+
+// float16to64, converts the IEEE 754 half-precision float h to a float64.
+func float16to64(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// ReadFloat32, reads a float from b, see ReadFloat64. ErrRange is returned
+// if the value overflows a float32.
+func ReadFloat32(b []byte) (float32, []byte, error) {
+	v, rest, err := ReadFloat64(b)
+	if err != nil {
+		return 0, b, err
+	}
+	f := float32(v)
+	if math.IsInf(float64(f), 0) && !math.IsInf(v, 0) {
+		return 0, b, ErrRange
+	}
+	return f, rest, nil
+}
+
+// readString, reads a text or byte string of major type major from b, its
+// bytes alias b.
+func readString(b []byte, major byte, typ string) ([]byte, []byte, error) {
+	m, _, n, rest, err := readHead(b)
+	if err != nil {
+		return nil, b, err
+	}
+	if m != major {
+		return nil, b, &TypeError{Type: typ, Initial: b[0]}
+	}
+	if n > uint64(len(rest)) {
+		return nil, b, ErrShortBytes
+	}
+	return rest[:n], rest[n:], nil
+}
+
+// ReadString, reads a text string from b. Byte strings are also accepted.
+func ReadString(b []byte) (string, []byte, error) {
+	if len(b) > 0 && b[0]>>5 == MajorBytes {
+		v, rest, err := readString(b, MajorBytes, "string")
+		return string(v), rest, err
+	}
+	v, rest, err := readString(b, MajorString, "string")
+	return string(v), rest, err
+}
+
+// ReadBytes, reads a byte string from b and returns a copy of its bytes.
+func ReadBytes(b []byte) ([]byte, []byte, error) {
+	v, rest, err := readString(b, MajorBytes, "bytes")
+	if err != nil {
+		return nil, b, err
+	}
+	return append([]byte{}, v...), rest, nil
+}
+
+// ReadTime, reads a time from b, either a RFC 3339 string (tag 0) or a
+// number of seconds since the epoch (tag 1). Epoch times are returned in
+// UTC.
+func ReadTime(b []byte) (time.Time, []byte, error) {
+	tag, rest, err := ReadTag(b)
+	if err != nil {
+		if _, ok := err.(*TypeError); ok {
+			err = &TypeError{Type: "time", Initial: b[0]}
+		}
+		return time.Time{}, b, err
+	}
+	switch tag {
+	case TagDateTime:
+		s, rest, err := readString(rest, MajorString, "time")
+		if err != nil {
+			return time.Time{}, b, err
+		}
+		t, err := time.Parse(time.RFC3339Nano, string(s))
+		if err != nil {
+			return time.Time{}, b, err
+		}
+		return t, rest, nil
+	case TagEpochDateTime:
+		if len(rest) > 0 && rest[0]>>5 <= MajorNegInt {
+			sec, rest, err := ReadInt(rest)
+			if err != nil {
+				return time.Time{}, b, err
+			}
+			return time.Unix(sec, 0).UTC(), rest, nil
+		}
+		f, rest, err := ReadFloat64(rest)
+		if err != nil {
+			return time.Time{}, b, err
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(f) >= 1<<63 {
+			return time.Time{}, b, ErrRange
+		}
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), rest, nil
+	}
+	return time.Time{}, b, &TypeError{Type: "time", Initial: b[0]}
+}
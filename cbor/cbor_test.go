@@ -0,0 +1,244 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+)
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Examples from RFC 8949, Appendix A.
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		in  int64
+		out string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{1000000000000, "1b000000e8d4a51000"},
+		{-1, "20"},
+		{-10, "29"},
+		{-100, "3863"},
+		{-1000, "3903e7"},
+		{math.MinInt64, "3b7fffffffffffffff"},
+	}
+	for _, test := range tests {
+		b := AppendInt(nil, test.in)
+		if hex.EncodeToString(b) != test.out {
+			t.Errorf("AppendInt(%d) = %x, want %s", test.in, b, test.out)
+		}
+		v, rest, err := ReadInt(b)
+		if err != nil || v != test.in || len(rest) != 0 {
+			t.Errorf("ReadInt(%x) = %d, %x, %v want %d", b, v, rest, err, test.in)
+		}
+	}
+	if v, _, err := ReadUint(unhex("1bffffffffffffffff")); v != math.MaxUint64 || err != nil {
+		t.Errorf("ReadUint(MaxUint64) = %d, %v", v, err)
+	}
+	for _, s := range []string{"1bffffffffffffffff", "3bffffffffffffffff"} {
+		if _, _, err := ReadInt(unhex(s)); err != ErrRange {
+			t.Errorf("ReadInt(%s): got error %v, want %v", s, err, ErrRange)
+		}
+	}
+	if _, _, err := ReadUint(unhex("20")); err != ErrRange {
+		t.Errorf("ReadUint(-1): got error %v, want %v", err, ErrRange)
+	}
+	if _, _, err := ReadInt(unhex("1a000f")); err != ErrShortBytes {
+		t.Errorf("ReadInt(short): got error %v, want %v", err, ErrShortBytes)
+	}
+	if _, _, err := ReadInt(unhex("f5")); err == nil {
+		t.Error("ReadInt(true): expected error")
+	}
+}
+
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+	}{
+		{"f90000", 0},
+		{"f93c00", 1},
+		{"f93e00", 1.5},
+		{"f97bff", 65504},
+		{"f90001", 5.960464477539063e-8},
+		{"f90400", 0.00006103515625},
+		{"f9c400", -4},
+		{"f97c00", math.Inf(1)},
+		{"f9fc00", math.Inf(-1)},
+		{"fa47c35000", 100000},
+		{"fa7f7fffff", 3.4028234663852886e+38},
+		{"fb3ff199999999999a", 1.1},
+		{"fb7e37e43c8800759c", 1.0e+300},
+		{"fbc010666666666666", -4.1},
+		{"1864", 100},
+		{"3863", -100},
+		{"c48221196ab3", 273.15},
+		{"c4822003", 0.3},
+	}
+	for _, test := range tests {
+		v, rest, err := ReadFloat64(unhex(test.in))
+		if err != nil || v != test.out || len(rest) != 0 {
+			t.Errorf("ReadFloat64(%s) = %v, %x, %v want %v", test.in, v, rest, err, test.out)
+		}
+	}
+	if v, _, _ := ReadFloat64(unhex("f97e00")); !math.IsNaN(v) {
+		t.Errorf("ReadFloat64(f97e00) = %v, want NaN", v)
+	}
+	if v, _, _ := ReadFloat64(unhex("f98000")); v != 0 || !math.Signbit(v) {
+		t.Errorf("ReadFloat64(f98000) = %v, want -0", v)
+	}
+	for _, test := range []struct {
+		in  float64
+		out string
+	}{
+		{100000, "fa47c35000"},
+		{1.1, "fb3ff199999999999a"},
+		{-4.1, "fbc010666666666666"},
+	} {
+		if b := AppendFloat64(nil, test.in); hex.EncodeToString(b) != test.out {
+			t.Errorf("AppendFloat64(%v) = %x, want %s", test.in, b, test.out)
+		}
+	}
+	if b := AppendFloat32(nil, 100000); hex.EncodeToString(b) != "fa47c35000" {
+		t.Errorf("AppendFloat32(100000) = %x, want fa47c35000", b)
+	}
+	if _, _, err := ReadFloat32(unhex("fb7e37e43c8800759c")); err != ErrRange {
+		t.Errorf("ReadFloat32(1e300): got error %v, want %v", err, ErrRange)
+	}
+	if _, _, err := ReadFloat64(unhex("6161")); err == nil {
+		t.Error("ReadFloat64(string): expected error")
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{"", "60"},
+		{"a", "6161"},
+		{"IETF", "6449455446"},
+		{"\"\\", "62225c"},
+		{"ü", "62c3bc"},
+	} {
+		b := AppendString(nil, test.in)
+		if hex.EncodeToString(b) != test.out {
+			t.Errorf("AppendString(%q) = %x, want %s", test.in, b, test.out)
+		}
+		v, rest, err := ReadString(b)
+		if err != nil || v != test.in || len(rest) != 0 {
+			t.Errorf("ReadString(%x) = %q, %x, %v want %q", b, v, rest, err, test.in)
+		}
+	}
+	b := AppendBytes(nil, []byte{1, 2, 3, 4})
+	if hex.EncodeToString(b) != "4401020304" {
+		t.Errorf("AppendBytes(01020304) = %x, want 4401020304", b)
+	}
+	if v, _, err := ReadBytes(b); !bytes.Equal(v, []byte{1, 2, 3, 4}) || err != nil {
+		t.Errorf("ReadBytes(%x) = %x, %v", b, v, err)
+	}
+	if v, _, err := ReadString(b); v != "\x01\x02\x03\x04" || err != nil {
+		t.Errorf("ReadString(%x) = %q, %v", b, v, err)
+	}
+	if _, _, err := ReadBytes(unhex("6161")); err == nil {
+		t.Error("ReadBytes(string): expected error")
+	}
+	if _, _, err := ReadString(unhex("6461")); err != ErrShortBytes {
+		t.Errorf("ReadString(short): got error %v, want %v", err, ErrShortBytes)
+	}
+	if _, _, err := ReadString(unhex("7f657374726561646d696e67ff")); err == nil {
+		t.Error("ReadString(indefinite length): expected error")
+	}
+}
+
+func TestSimple(t *testing.T) {
+	b := AppendNull(AppendBool(AppendBool(nil, false), true))
+	if hex.EncodeToString(b) != "f4f5f6" {
+		t.Errorf("got %x, want f4f5f6", b)
+	}
+	v, b, err := ReadBool(b)
+	if v || err != nil {
+		t.Errorf("ReadBool: got %v, %v want false", v, err)
+	}
+	v, b, err = ReadBool(b)
+	if !v || err != nil {
+		t.Errorf("ReadBool: got %v, %v want true", v, err)
+	}
+	if b, err = ReadNull(b); len(b) != 0 || err != nil {
+		t.Errorf("ReadNull: got %x, %v", b, err)
+	}
+	if !IsNull(unhex("f7")) {
+		t.Error("IsNull(undefined) = false")
+	}
+	if _, err := ReadNull(unhex("00")); err == nil {
+		t.Error("ReadNull(0): expected error")
+	}
+}
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		in  time.Time
+		out string
+	}{
+		{time.Unix(1363896240, 0), "c11a514b67b0"},
+		{time.Unix(-1, 0), "c120"},
+		{
+			time.Date(2013, 3, 21, 20, 4, 0, 500000000, time.UTC),
+			"c076323031332d30332d32315432303a30343a30302e355a",
+		},
+		// Years outside of RFC 3339 use floating-point epoch seconds.
+		{time.Date(-1, 1, 1, 0, 0, 0, 500000000, time.UTC), "c1fbc22cf6ab5eff0000"},
+		{time.Date(10000, 1, 1, 0, 0, 0, 250000000, time.UTC), "c1fb424d7ffa20c02000"},
+	}
+	for _, test := range tests {
+		b := AppendTime(nil, test.in)
+		if hex.EncodeToString(b) != test.out {
+			t.Errorf("AppendTime(%v) = %x, want %s", test.in, b, test.out)
+		}
+		v, rest, err := ReadTime(b)
+		if err != nil || !v.Equal(test.in) || len(rest) != 0 {
+			t.Errorf("ReadTime(%x) = %v, %x, %v want %v", b, v, rest, err, test.in)
+		}
+	}
+	for _, test := range []struct {
+		in  string
+		out time.Time
+	}{
+		{"c074323031332d30332d32315432303a30343a30305a", time.Unix(1363896240, 0)},
+		{"c1fb41d452d9ec200000", time.Unix(1363896240, 500000000)},
+	} {
+		v, _, err := ReadTime(unhex(test.in))
+		if err != nil || !v.Equal(test.out) {
+			t.Errorf("ReadTime(%s) = %v, %v want %v", test.in, v, err, test.out)
+		}
+	}
+	for _, s := range []string{"c26161", "c06161", "c1f97c00", "1a514b67b0", "c1"} {
+		if _, _, err := ReadTime(unhex(s)); err == nil {
+			t.Errorf("ReadTime(%s): expected error", s)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	b := AppendDecimal(nil, -2, 27315)
+	if hex.EncodeToString(b) != "c48221196ab3" {
+		t.Errorf("AppendDecimal(-2, 27315) = %x, want c48221196ab3", b)
+	}
+	if _, _, err := ReadFloat64(unhex("c4832103")); err == nil {
+		t.Error("ReadFloat64(4([-2, 3, ...])): expected error")
+	}
+}
@@ -0,0 +1,83 @@
+package null
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCBOR(t *testing.T) {
+	tests := []struct {
+		in interface {
+			MarshalCBOR() ([]byte, error)
+		}
+		out string
+	}{
+		{Int{}, "f6"},
+		{NewInt(-1000), "3903e7"},
+		{Uint64{}, "f6"},
+		{NewUint64(18446744073709551615), "1bffffffffffffffff"},
+		{Float64{}, "f6"},
+		{NewFloat64(1.1), "fb3ff199999999999a"},
+		{Float32{}, "f6"},
+		{NewFloat32(1.5), "fa3fc00000"},
+		{String{}, "f6"},
+		{NewString("IETF"), "6449455446"},
+		{UnescapedString{}, "f6"},
+		{NewUnescapedString("a"), "6161"},
+		{Bool{}, "f6"},
+		{NewBool(false), "f4"},
+		{Time{}, "f6"},
+		{NewTime(time.Unix(1363896240, 0).UTC()), "c11a514b67b0"},
+	}
+	for _, test := range tests {
+		b, err := test.in.MarshalCBOR()
+		if err != nil {
+			t.Errorf("MarshalCBOR(%+v): %v", test.in, err)
+			continue
+		}
+		if fmt.Sprintf("%x", b) != test.out {
+			t.Errorf("MarshalCBOR(%+v) = %x, want %s", test.in, b, test.out)
+		}
+		v := newTextUnmarshaler(test.in)
+		u := v.(interface{ UnmarshalCBOR([]byte) error })
+		if err := u.UnmarshalCBOR(b); err != nil {
+			t.Errorf("%T: UnmarshalCBOR(%x): %v", test.in, b, err)
+			continue
+		}
+		if !textEqual(v, test.in) {
+			t.Errorf("%T: UnmarshalCBOR(%x) = %+v, want %+v", test.in, b, v, test.in)
+		}
+		if err := u.UnmarshalCBOR(append(b, 0xf6)); err == nil {
+			t.Errorf("%T: UnmarshalCBOR(%x): expected trailing data error", test.in, append(b, 0xf6))
+		}
+	}
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	var f Float64
+	if err := f.UnmarshalCBOR([]byte{0xc4, 0x82, 0x21, 0x19, 0x6a, 0xb3}); err != nil || f != NewFloat64(273.15) {
+		t.Errorf("Float64: UnmarshalCBOR(decimal fraction) = %+v, %v", f, err)
+	}
+	var s String
+	if err := s.UnmarshalCBOR([]byte{0x42, 'h', 'i'}); err != nil || s != NewString("hi") {
+		t.Errorf("String: UnmarshalCBOR(bytes) = %+v, %v", s, err)
+	}
+	i := NewInt(1)
+	if err := i.UnmarshalCBOR([]byte{0xf7}); err != nil || i.Valid {
+		t.Errorf("Int: UnmarshalCBOR(undefined) = %+v, %v", i, err)
+	}
+	var u Uint64
+	if err := u.UnmarshalCBOR([]byte{0x20}); err == nil || u.Valid {
+		t.Errorf("Uint64: UnmarshalCBOR(-1) = %+v, %v: want error", u, err)
+	}
+	var tm Time
+	if err := tm.UnmarshalCBOR([]byte{0x1a, 0x51, 0x4b, 0x67, 0xb0}); err == nil || tm.Valid {
+		t.Errorf("Time: UnmarshalCBOR(untagged int) = %+v, %v: want error", tm, err)
+	}
+	in := NewTime(time.Date(2017, 10, 20, 23, 4, 56, 123, time.FixedZone("", 3600)))
+	b, _ := in.MarshalCBOR()
+	if err := tm.UnmarshalCBOR(b); err != nil || !tm.Time.Equal(in.Time) {
+		t.Errorf("Time: UnmarshalCBOR(%x) = %+v, %v want %v", b, tm, err, in.Time)
+	}
+}
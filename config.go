@@ -0,0 +1,350 @@
+package null
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// This file implements the marshaler hooks used by YAML and TOML libraries,
+// without depending on them:
+//
+//	gopkg.in/yaml.v2 and v3    MarshalYAML() (interface{}, error)
+//	                           UnmarshalYAML(func(interface{}) error) error
+//	github.com/BurntSushi/toml UnmarshalTOML(interface{}) error
+//
+// Null (~) and absent keys unmarshal as NULL. Scalars are parsed with the
+// same rules as UnmarshalJSON, numbers and booleans may also be quoted.
+// Quoted booleans are parsed like the JSON strings "true" and "false" by
+// DefaultDecoder, so "1" and "t" are only accepted in DecodeLenient mode and
+// the string "null" is an error.
+// TOML has no null, NULL values are marshaled with MarshalText and should be
+// omitted with the omitzero tag option.
+
+// unmarshalYAML, unmarshals a YAML scalar into a generic value for set.
+func unmarshalYAML(unmarshal func(interface{}) error, set func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return set(v)
+}
+
+func unsupportedValue(value interface{}, typ string) error {
+	return &UnmarshalError{
+		Type:  "null." + typ,
+		Value: errorValue(fmt.Sprintf("%#v", value)),
+		Err:   ErrUnsupportedType,
+	}
+}
+
+// valueError, returns err as an *UnmarshalError for the YAML or TOML value,
+// unless it already is one.
+func valueError(value interface{}, typ string, err error) error {
+	if _, ok := err.(*UnmarshalError); ok {
+		return err
+	}
+	s, ok := value.(string)
+	if !ok {
+		s = fmt.Sprintf("%#v", value)
+	}
+	return newUnmarshalError("null."+typ, []byte(s), err)
+}
+
+func (i *Int) setValue(value interface{}) error {
+	var n int64
+	var err error
+	switch v := value.(type) {
+	case nil:
+		i.Int, i.Valid = 0, false
+		return nil
+	case string:
+		n, err = parseInt([]byte(v), strconv.IntSize)
+	case float32, float64:
+		err = unsupportedValue(value, "Int")
+	default:
+		n, err = convertInt(value, strconv.IntSize)
+		if err == ErrUnsupportedType {
+			err = unsupportedValue(value, "Int")
+		}
+	}
+	if err != nil {
+		i.Int, i.Valid = 0, false
+		return valueError(value, "Int", err)
+	}
+	i.Int, i.Valid = int(n), true
+	return nil
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (i Int) MarshalYAML() (interface{}, error) {
+	if i.Valid {
+		return i.Int, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface.
+func (i *Int) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, i.setValue)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (i *Int) UnmarshalTOML(value interface{}) error {
+	return i.setValue(value)
+}
+
+func (u *Uint64) setValue(value interface{}) error {
+	var n uint64
+	var err error
+	switch v := value.(type) {
+	case nil:
+		u.Uint64, u.Valid = 0, false
+		return nil
+	case string:
+		n, err = parseUint([]byte(v), 64)
+	case float32, float64:
+		err = unsupportedValue(value, "Uint64")
+	default:
+		n, err = convertUint(value, 64)
+		if err == ErrUnsupportedType {
+			err = unsupportedValue(value, "Uint64")
+		}
+	}
+	if err != nil {
+		u.Uint64, u.Valid = 0, false
+		return valueError(value, "Uint64", err)
+	}
+	u.Uint64, u.Valid = n, true
+	return nil
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (u Uint64) MarshalYAML() (interface{}, error) {
+	if u.Valid {
+		return u.Uint64, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface.
+func (u *Uint64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, u.setValue)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (u *Uint64) UnmarshalTOML(value interface{}) error {
+	return u.setValue(value)
+}
+
+// convertFloatValue, converts a YAML or TOML value to a float.
+func convertFloatValue(value interface{}, bitSize int, typ string) (float64, error) {
+	switch v := value.(type) {
+	case string:
+		return parseFloat([]byte(v), bitSize)
+	case bool:
+		return 0, unsupportedValue(value, typ)
+	}
+	f, err := convertFloat(value, 64)
+	if err == ErrUnsupportedType {
+		return 0, unsupportedValue(value, typ)
+	}
+	if err == nil && bitSize == 32 && math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+		err = &strconv.NumError{Func: "ParseFloat", Num: strconv.FormatFloat(f, 'g', -1, 64), Err: strconv.ErrRange}
+	}
+	return f, err
+}
+
+func (f *Float64) setValue(value interface{}) error {
+	if value == nil {
+		f.Float64, f.Valid = 0, false
+		return nil
+	}
+	n, err := convertFloatValue(value, 64, "Float64")
+	if err != nil {
+		f.Float64, f.Valid = 0, false
+		return valueError(value, "Float64", err)
+	}
+	f.Float64, f.Valid = n, true
+	return nil
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (f Float64) MarshalYAML() (interface{}, error) {
+	if f.Valid {
+		return f.Float64, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface.
+func (f *Float64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, f.setValue)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (f *Float64) UnmarshalTOML(value interface{}) error {
+	return f.setValue(value)
+}
+
+func (f *Float32) setValue(value interface{}) error {
+	if value == nil {
+		f.Float32, f.Valid = 0, false
+		return nil
+	}
+	n, err := convertFloatValue(value, 32, "Float32")
+	if err != nil {
+		f.Float32, f.Valid = 0, false
+		return valueError(value, "Float32", err)
+	}
+	f.Float32, f.Valid = float32(n), true
+	return nil
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (f Float32) MarshalYAML() (interface{}, error) {
+	if f.Valid {
+		return f.Float32, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface.
+func (f *Float32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, f.setValue)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (f *Float32) UnmarshalTOML(value interface{}) error {
+	return f.setValue(value)
+}
+
+func (s *String) setValue(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		s.String, s.Valid = "", false
+	case string:
+		s.String, s.Valid = v, true
+	case []byte:
+		s.String, s.Valid = string(v), true
+	default:
+		s.String, s.Valid = "", false
+		return unsupportedValue(value, "String")
+	}
+	return nil
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (s String) MarshalYAML() (interface{}, error) {
+	if s.Valid {
+		return s.String, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface. Only string
+// scalars are accepted, quote numbers and booleans to use them as a String.
+func (s *String) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, s.setValue)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (s *String) UnmarshalTOML(value interface{}) error {
+	return s.setValue(value)
+}
+
+func (s *UnescapedString) setValue(value interface{}) error {
+	return (*String)(s).setValue(value)
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (s UnescapedString) MarshalYAML() (interface{}, error) {
+	return String(s).MarshalYAML()
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface.
+func (s *UnescapedString) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return (*String)(s).UnmarshalYAML(unmarshal)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (s *UnescapedString) UnmarshalTOML(value interface{}) error {
+	return (*String)(s).UnmarshalTOML(value)
+}
+
+func (b *Bool) setValue(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		b.Bool, b.Valid = false, false
+	case bool:
+		b.Bool, b.Valid = v, true
+	case string:
+		// Parsed like the JSON string v by UnmarshalJSON, so "null" is
+		// not NULL.
+		var enc Encoder
+		return DefaultDecoder.UnmarshalBool(enc.appendString(nil, v), b)
+	default:
+		b.Bool, b.Valid = false, false
+		return unsupportedValue(value, "Bool")
+	}
+	return nil
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (b Bool) MarshalYAML() (interface{}, error) {
+	if b.Valid {
+		return b.Bool, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface.
+func (b *Bool) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, b.setValue)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (b *Bool) UnmarshalTOML(value interface{}) error {
+	return b.setValue(value)
+}
+
+func (t *Time) setValue(value interface{}) error {
+	var err error
+	switch v := value.(type) {
+	case nil:
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		t.Time = v
+	case string:
+		t.Time, err = DefaultDecoder.parseTimeText([]byte(v))
+	default:
+		err = unsupportedValue(value, "Time")
+	}
+	if err != nil {
+		t.Time, t.Valid = time.Time{}, false
+		return valueError(value, "Time", err)
+	}
+	t.Valid = true
+	return nil
+}
+
+// MarshalYAML, implements the yaml.Marshaler interface.
+func (t Time) MarshalYAML() (interface{}, error) {
+	if t.Valid {
+		return t.Time, nil
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML, implements the yaml.Unmarshaler interface. Timestamps
+// and strings in one of the TimeFormats of DefaultDecoder are accepted.
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalYAML(unmarshal, t.setValue)
+}
+
+// UnmarshalTOML, implements the toml.Unmarshaler interface.
+func (t *Time) UnmarshalTOML(value interface{}) error {
+	return t.setValue(value)
+}
@@ -0,0 +1,189 @@
+package null
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// yamlValue, returns an unmarshal function, as passed to UnmarshalYAML, that
+// decodes the scalar v.
+func yamlValue(v interface{}) func(interface{}) error {
+	return func(out interface{}) error {
+		*out.(*interface{}) = v
+		return nil
+	}
+}
+
+type configUnmarshaler interface {
+	UnmarshalYAML(func(interface{}) error) error
+	UnmarshalTOML(interface{}) error
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	tm := time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC)
+	tests := []struct {
+		in  interface{}
+		out interface{}
+	}{
+		{nil, Int{}},
+		{1, NewInt(1)},
+		{int64(-2), NewInt(-2)},
+		{uint64(3), NewInt(3)},
+		{"4", NewInt(4)},
+		{nil, Uint64{}},
+		{1, NewUint64(1)},
+		{uint64(math.MaxUint64), NewUint64(math.MaxUint64)},
+		{"18446744073709551615", NewUint64(math.MaxUint64)},
+		{nil, Float64{}},
+		{1.5, NewFloat64(1.5)},
+		{2, NewFloat64(2)},
+		{int64(-3), NewFloat64(-3)},
+		{"4.5", NewFloat64(4.5)},
+		{nil, Float32{}},
+		{1.5, NewFloat32(1.5)},
+		{-1, NewFloat32(-1)},
+		{0, NewFloat32(0)},
+		{"-2.5", NewFloat32(-2.5)},
+		{nil, String{}},
+		{"", NewString("")},
+		{"a", NewString("a")},
+		{nil, UnescapedString{}},
+		{"<", NewUnescapedString("<")},
+		{nil, Bool{}},
+		{true, NewBool(true)},
+		{"false", NewBool(false)},
+		{nil, Time{}},
+		{tm, NewTime(tm)},
+		{"2017-10-20T23:04:56Z", NewTime(tm)},
+	}
+	for _, test := range tests {
+		for _, format := range []string{"YAML", "TOML"} {
+			v := newTextUnmarshaler(test.out)
+			u := v.(configUnmarshaler)
+			var err error
+			if format == "YAML" {
+				err = u.UnmarshalYAML(yamlValue(test.in))
+			} else {
+				err = u.UnmarshalTOML(test.in)
+			}
+			if err != nil {
+				t.Errorf("%T: Unmarshal%s(%#v): %v", test.out, format, test.in, err)
+				continue
+			}
+			if !textEqual(v, test.out) {
+				t.Errorf("%T: Unmarshal%s(%#v) = %+v, want %+v", test.out, format, test.in, v, test.out)
+			}
+		}
+	}
+}
+
+func TestUnmarshalConfigBoolMode(t *testing.T) {
+	defer func(d Decoder) { DefaultDecoder = d }(DefaultDecoder)
+	DefaultDecoder.Mode = DecodeLenient
+
+	for in, want := range map[string]Bool{"1": NewBool(true), "t": NewBool(true), "F": NewBool(false)} {
+		var b Bool
+		if err := b.UnmarshalTOML(in); err != nil || b != want {
+			t.Errorf("UnmarshalTOML(%q) = %+v, %v want %+v", in, b, err, want)
+		}
+	}
+	var b Bool
+	if err := b.UnmarshalTOML("null"); err == nil {
+		t.Errorf("UnmarshalTOML(%q) = %+v: expected error", "null", b)
+	}
+}
+
+func TestUnmarshalConfigErrors(t *testing.T) {
+	tests := []struct {
+		v  configUnmarshaler
+		in interface{}
+	}{
+		{new(Int), 1.5},
+		{new(Int), "1.5"},
+		{new(Int), true},
+		{new(Int), uint64(math.MaxUint64)},
+		{new(Uint64), -1},
+		{new(Uint64), 1.5},
+		{new(Uint64), "-1"},
+		{new(Float64), true},
+		{new(Float64), "x"},
+		{new(Float64), "1e400"},
+		{new(Uint64), "x"},
+		{new(Int), "99999999999999999999"},
+		{new(Float32), math.MaxFloat64},
+		{new(Float32), "1e300"},
+		{new(String), 1},
+		{new(Bool), 1},
+		{new(Bool), "yes"},
+		{new(Bool), "null"},
+		{new(Bool), "1"},
+		{new(Bool), "0"},
+		{new(Bool), "t"},
+		{new(Bool), "F"},
+		{new(Bool), ""},
+		{new(Time), 1},
+		{new(Time), "2017-10-20"},
+	}
+	for _, test := range tests {
+		var uerr *UnmarshalError
+		if err := test.v.UnmarshalTOML(test.in); !errors.As(err, &uerr) {
+			t.Errorf("%T: UnmarshalTOML(%#v) = %v: expected *UnmarshalError", test.v, test.in, err)
+		}
+		if err := test.v.UnmarshalYAML(yamlValue(test.in)); err == nil {
+			t.Errorf("%T: UnmarshalYAML(%#v): expected error", test.v, test.in)
+		}
+	}
+	want := errors.New("yaml: error")
+	var i Int
+	if err := i.UnmarshalYAML(func(interface{}) error { return want }); err != want {
+		t.Errorf("UnmarshalYAML: got error %v, want %v", err, want)
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	tm := time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC)
+	tests := []struct {
+		in interface {
+			MarshalYAML() (interface{}, error)
+		}
+		out interface{}
+	}{
+		{Int{}, nil},
+		{NewInt(1), 1},
+		{Uint64{}, nil},
+		{NewUint64(math.MaxUint64), uint64(math.MaxUint64)},
+		{Float64{}, nil},
+		{NewFloat64(1.5), 1.5},
+		{Float32{}, nil},
+		{NewFloat32(1.5), float32(1.5)},
+		{String{}, nil},
+		{NewString("a"), "a"},
+		{UnescapedString{}, nil},
+		{NewUnescapedString("a"), "a"},
+		{Bool{}, nil},
+		{NewBool(true), true},
+		{Time{}, nil},
+		{NewTime(tm), tm},
+	}
+	for _, test := range tests {
+		v, err := test.in.MarshalYAML()
+		if err != nil || v != test.out {
+			t.Errorf("MarshalYAML(%+v) = %#v, %v want %#v", test.in, v, err, test.out)
+		}
+	}
+}
+
+func TestUnmarshalConfigErrorType(t *testing.T) {
+	var i Int
+	err := i.UnmarshalTOML("abc")
+	var uerr *UnmarshalError
+	if !errors.As(err, &uerr) || uerr.Type != "null.Int" || uerr.Value != "abc" || !errors.Is(err, ErrSyntax) {
+		t.Errorf("UnmarshalTOML(%q) = %#v want *UnmarshalError wrapping %v", "abc", err, ErrSyntax)
+	}
+	var f Float32
+	if err := f.UnmarshalYAML(yamlValue("1e300")); !errors.As(err, &uerr) || !errors.Is(err, ErrRange) {
+		t.Errorf("UnmarshalYAML(%q) = %v want *UnmarshalError wrapping %v", "1e300", err, ErrRange)
+	}
+}
@@ -0,0 +1,114 @@
+package null
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// baseValue, returns the database value value converted to int64, uint64,
+// float64 or string if it is of a named type with one of these underlying
+// kinds, e.g. a type MyInt int32. Like database/sql, this allows drivers and
+// Valuers to return named types.
+func baseValue(value interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		return rv.String(), true
+	}
+	return nil, false
+}
+
+// convertBool, converts the database value value to a bool. The values
+// accepted by database/sql are accepted: booleans, the integers 0 and 1, and
+// strings accepted by strconv.ParseBool, e.g. "1", "t" or "FALSE". In
+// addition "yes" and "no" are accepted in any case, as are named types.
+func convertBool(value interface{}) (bool, error) {
+	var s string
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Bool:
+			return rv.Bool(), nil
+		case reflect.String:
+			s = rv.String()
+		default:
+			v, ok := baseValue(value)
+			if !ok {
+				return false, ErrUnsupportedType
+			}
+			switch v := v.(type) {
+			case int64:
+				if v == 0 || v == 1 {
+					return v == 1, nil
+				}
+				return false, ErrRange
+			case uint64:
+				if v == 0 || v == 1 {
+					return v == 1, nil
+				}
+				return false, ErrRange
+			}
+			return false, ErrUnsupportedType
+		}
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b, nil
+	}
+	switch {
+	case strings.EqualFold(s, "yes"):
+		return true, nil
+	case strings.EqualFold(s, "no"):
+		return false, nil
+	}
+	return false, ErrSyntax
+}
+
+// convertString, converts the database value value to a string. Like
+// database/sql, booleans and numbers are formatted and time.Time values are
+// formatted with time.RFC3339Nano, unless the StringLayout of TimePolicy p is
+// set. Times are normalized by p before they are formatted.
+func (p *TimePolicy) convertString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		layout := p.StringLayout
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		return p.Normalize(v).Format(layout), nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	case reflect.String:
+		return rv.String(), nil
+	}
+	return "", ErrUnsupportedType
+}
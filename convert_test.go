@@ -0,0 +1,203 @@
+package null
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type (
+	namedInt    int32
+	namedUint   uint16
+	namedFloat  float32
+	namedString string
+	namedBool   bool
+)
+
+// scanSources, are the database values scanned by TestScanCompatibility.
+var scanSources = []interface{}{
+	int64(0), int64(1), int64(-1), int64(2), int64(math.MaxInt64), int64(math.MinInt64),
+	int(7), int8(-8), int16(16), int32(-32), uint(1), uint8(8), uint16(16), uint32(32),
+	uint64(0), uint64(math.MaxUint64),
+	float64(0), math.Copysign(0, -1), float64(1), float64(-3), float64(1.5), float64(1e20),
+	float64(math.MaxFloat64), math.Inf(1), float32(2), float32(2.5),
+	true, false,
+	"", "0", "1", "-1", "2", "1.5", "1e3", " 1", "t", "f", "T", "TRUE", "False",
+	"yes", "No", "maybe", "9223372036854775808", "2006-01-02 15:04:05",
+	"1.00000005960464477550", "1e-50", "3.4028235677973366e+38",
+	[]byte("0"), []byte("1"), []byte("42"), []byte("1.25"), []byte("true"), []byte("x"),
+	time.Date(2006, 1, 2, 15, 4, 5, 999, time.UTC), time.Time{},
+	namedInt(5), namedUint(6), namedFloat(0.5), namedString("3"), namedBool(true),
+	struct{}{}, []int{1},
+}
+
+// scanExtensions, are the database values accepted by Scan, but not by the
+// matching sql.Null type.
+var scanExtensions = map[string][]interface{}{
+	"Bool": {"yes", "No", namedBool(true)},
+	"Time": {"2006-01-02 15:04:05"},
+}
+
+type scanPair struct {
+	name string
+	ours func(interface{}) (interface{}, error)
+	sql  func(interface{}) (interface{}, error)
+}
+
+var scanPairs = []scanPair{
+	{
+		"Int",
+		func(v interface{}) (interface{}, error) {
+			var n Int
+			err := n.Scan(v)
+			return int64(n.Int), err
+		},
+		func(v interface{}) (interface{}, error) {
+			var n sql.NullInt64
+			err := n.Scan(v)
+			return n.Int64, err
+		},
+	},
+	{
+		"Float64",
+		func(v interface{}) (interface{}, error) {
+			var n Float64
+			err := n.Scan(v)
+			return n.Float64, err
+		},
+		func(v interface{}) (interface{}, error) {
+			var n sql.NullFloat64
+			err := n.Scan(v)
+			return n.Float64, err
+		},
+	},
+	{
+		"Float32",
+		func(v interface{}) (interface{}, error) {
+			var n Float32
+			err := n.Scan(v)
+			return n.Float32, err
+		},
+		func(v interface{}) (interface{}, error) {
+			var n sql.Null[float32]
+			err := n.Scan(v)
+			return n.V, err
+		},
+	},
+	{
+		"String",
+		func(v interface{}) (interface{}, error) {
+			var n String
+			err := n.Scan(v)
+			return n.String, err
+		},
+		func(v interface{}) (interface{}, error) {
+			var n sql.NullString
+			err := n.Scan(v)
+			return n.String, err
+		},
+	},
+	{
+		"Bool",
+		func(v interface{}) (interface{}, error) {
+			var n Bool
+			err := n.Scan(v)
+			return n.Bool, err
+		},
+		func(v interface{}) (interface{}, error) {
+			var n sql.NullBool
+			err := n.Scan(v)
+			return n.Bool, err
+		},
+	},
+	{
+		"Time",
+		func(v interface{}) (interface{}, error) {
+			var n Time
+			err := n.Scan(v)
+			return n.Time, err
+		},
+		func(v interface{}) (interface{}, error) {
+			var n sql.NullTime
+			err := n.Scan(v)
+			return n.Time, err
+		},
+	},
+}
+
+func isScanExtension(typ string, v interface{}) bool {
+	for _, x := range scanExtensions[typ] {
+		if reflect.DeepEqual(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestScanCompatibility, tests that Scan accepts every value accepted by
+// the sql.Null type of the same kind, with the same result.
+func TestScanCompatibility(t *testing.T) {
+	for _, pair := range scanPairs {
+		for _, in := range scanSources {
+			want, wantErr := pair.sql(in)
+			got, err := pair.ours(in)
+			switch {
+			case wantErr == nil && err != nil:
+				t.Errorf("%s.Scan(%#v): %v: sql.Null accepts %#v", pair.name, in, err, want)
+			case wantErr == nil && got != want:
+				t.Errorf("%s.Scan(%#v) = %#v want %#v", pair.name, in, got, want)
+			case wantErr != nil && err == nil:
+				if !isScanExtension(pair.name, in) {
+					t.Errorf("%s.Scan(%#v) = %#v: sql.Null rejects it: %v", pair.name, in, got, wantErr)
+				}
+			}
+		}
+	}
+}
+
+func TestScanFloatIntoInt(t *testing.T) {
+	tests := []struct {
+		in  interface{}
+		out int
+		err error
+	}{
+		{float64(42), 42, nil},
+		{float64(-42), -42, nil},
+		{float32(3), 3, nil},
+		{float64(1.5), 0, ErrSyntax},
+		{math.NaN(), 0, ErrSyntax},
+		{math.Inf(-1), 0, ErrRange},
+		{float64(1e19), 0, ErrRange},
+	}
+	for _, test := range tests {
+		var n Int
+		err := n.Scan(test.in)
+		if test.err != nil {
+			if !errors.Is(err, test.err) || n.Valid {
+				t.Errorf("Int.Scan(%v) = %+v, %v want error %v", test.in, n, err, test.err)
+			}
+			continue
+		}
+		if err != nil || !n.Valid || n.Int != test.out {
+			t.Errorf("Int.Scan(%v) = %+v, %v want %d", test.in, n, err, test.out)
+		}
+	}
+}
+
+func TestScanTimeIntoString(t *testing.T) {
+	defer func(p TimePolicy) { DefaultTimePolicy = p }(DefaultTimePolicy)
+
+	in := time.Date(2006, 1, 2, 15, 4, 5, 0, time.FixedZone("", -7*3600))
+	var s String
+	if err := s.Scan(in); err != nil || s.String != "2006-01-02T15:04:05-07:00" {
+		t.Errorf("String.Scan(%v) = %+v, %v", in, s, err)
+	}
+
+	DefaultTimePolicy = TimePolicy{Location: time.UTC, StringLayout: "2006-01-02 15:04:05"}
+	if err := s.Scan(in); err != nil || s.String != "2006-01-02 22:04:05" {
+		t.Errorf("String.Scan(%v) with StringLayout = %+v, %v", in, s, err)
+	}
+}
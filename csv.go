@@ -0,0 +1,552 @@
+package null
+
+import (
+	"bufio"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// NULL representations of CSVReader and CSVWriter. Any other text may be
+// used as well.
+const (
+	// CSVNullEmpty, represents NULL as an unquoted empty field, which is
+	// the default of the Postgres COPY CSV format. An empty string is
+	// written as a quoted empty field ("").
+	CSVNullEmpty = ""
+
+	// CSVNullEscape, represents NULL as \N, which is used by MySQL
+	// SELECT ... INTO OUTFILE and LOAD DATA.
+	CSVNullEscape = `\N`
+)
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface. NULL is
+// marshaled as NullText, use a CSVWriter to write NULL and empty strings
+// differently.
+func (i Int) MarshalCSV() (string, error) { return marshalCSV(i) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface. Text equal
+// to NullText unmarshals as NULL.
+func (i *Int) UnmarshalCSV(s string) error { return i.UnmarshalText([]byte(s)) }
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface, see Int.
+func (u Uint64) MarshalCSV() (string, error) { return marshalCSV(u) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface, see Int.
+func (u *Uint64) UnmarshalCSV(s string) error { return u.UnmarshalText([]byte(s)) }
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface, see Int.
+func (f Float64) MarshalCSV() (string, error) { return marshalCSV(f) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface, see Int.
+func (f *Float64) UnmarshalCSV(s string) error { return f.UnmarshalText([]byte(s)) }
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface, see Int.
+func (f Float32) MarshalCSV() (string, error) { return marshalCSV(f) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface, see Int.
+func (f *Float32) UnmarshalCSV(s string) error { return f.UnmarshalText([]byte(s)) }
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface, see Int.
+func (s String) MarshalCSV() (string, error) { return marshalCSV(s) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface, see Int.
+func (s *String) UnmarshalCSV(text string) error { return s.UnmarshalText([]byte(text)) }
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface, see Int.
+func (s UnescapedString) MarshalCSV() (string, error) { return marshalCSV(s) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface, see Int.
+func (s *UnescapedString) UnmarshalCSV(text string) error { return s.UnmarshalText([]byte(text)) }
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface, see Int.
+func (b Bool) MarshalCSV() (string, error) { return marshalCSV(b) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface, see Int.
+func (b *Bool) UnmarshalCSV(s string) error { return b.UnmarshalText([]byte(s)) }
+
+// MarshalCSV, implements the gocsv TypeMarshaller interface, see Int.
+func (t Time) MarshalCSV() (string, error) { return marshalCSV(t) }
+
+// UnmarshalCSV, implements the gocsv TypeUnmarshaller interface, see Int.
+func (t *Time) UnmarshalCSV(s string) error { return t.UnmarshalText([]byte(s)) }
+
+func marshalCSV(v encoding.TextMarshaler) (string, error) {
+	b, err := v.MarshalText()
+	return string(b), err
+}
+
+// textValue, is implemented by the types of this package.
+type textValue interface {
+	driver.Valuer
+	encoding.TextMarshaler
+}
+
+// valueSetter, is implemented by pointers to the types of this package.
+type valueSetter interface {
+	setValue(value interface{}) error
+}
+
+// textParser, is implemented by pointers to the types of this package.
+type textParser interface {
+	valueSetter
+	parseText(text []byte) error
+}
+
+// A CSVWriter writes records to a CSV file. Unlike a csv.Writer it writes
+// NULL fields as the Null text and quotes non-NULL fields that are equal to
+// it, so that NULL and empty strings can be told apart.
+//
+// Writes are buffered, Flush must be called to write any buffered data.
+type CSVWriter struct {
+	Comma   rune   // field delimiter, set to ',' by NewCSVWriter
+	Null    string // text of NULL fields, e.g. CSVNullEmpty or CSVNullEscape
+	UseCRLF bool   // end lines with \r\n instead of \n
+
+	w      *bufio.Writer
+	record []String
+}
+
+// NewCSVWriter, returns a CSVWriter that writes to w and represents NULL as
+// an unquoted empty field.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{Comma: ',', Null: CSVNullEmpty, w: bufio.NewWriter(w)}
+}
+
+// fieldNeedsQuotes, returns if field s must be quoted.
+func (w *CSVWriter) fieldNeedsQuotes(s string) bool {
+	return s == w.Null || strings.ContainsRune(s, w.Comma) ||
+		strings.ContainsAny(s, "\"\r\n")
+}
+
+// Write, writes a single record, invalid Strings are written as NULL.
+func (w *CSVWriter) Write(record []String) error {
+	for i, field := range record {
+		if i > 0 {
+			w.w.WriteRune(w.Comma)
+		}
+		switch {
+		case !field.Valid:
+			w.w.WriteString(w.Null)
+		case !w.fieldNeedsQuotes(field.String):
+			w.w.WriteString(field.String)
+		default:
+			w.w.WriteByte('"')
+			w.w.WriteString(strings.ReplaceAll(field.String, `"`, `""`))
+			w.w.WriteByte('"')
+		}
+	}
+	var err error
+	if w.UseCRLF {
+		_, err = w.w.WriteString("\r\n")
+	} else {
+		err = w.w.WriteByte('\n')
+	}
+	return err
+}
+
+// WriteAll, writes multiple records and flushes the writer.
+func (w *CSVWriter) WriteAll(records [][]String) error {
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Flush, writes any buffered data to the underlying io.Writer.
+func (w *CSVWriter) Flush() error {
+	return w.w.Flush()
+}
+
+var errCSVStruct = errors.New("null: CSV: value must be a struct or a non-nil pointer to a struct")
+
+// csvStruct, returns the struct value v or the struct v points to.
+func csvStruct(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, errCSVStruct
+	}
+	return rv, nil
+}
+
+// WriteHeader, writes the column names of the struct v, or the struct v
+// points to, in the order they are written by WriteStruct. Columns are
+// named by the `csv:"name"` struct tag, the `db:"name"` tag or else by the
+// field name, see ScanStruct.
+func (w *CSVWriter) WriteHeader(v interface{}) error {
+	rv, err := csvStruct(v)
+	if err != nil {
+		return err
+	}
+	p := planFor(rv.Type(), "csv", "db")
+	record := w.record[:0]
+	for _, name := range p.columns {
+		record = append(record, NewString(name))
+	}
+	w.record = record
+	return w.Write(record)
+}
+
+// WriteStruct, writes the fields of the struct v, or the struct v points to,
+// as a record. Fields of the types of this package and nil pointers are
+// written as NULL when they are invalid, other fields are written with
+// their MarshalText method or else formatted like String.Scan formats them.
+func (w *CSVWriter) WriteStruct(v interface{}) error {
+	rv, err := csvStruct(v)
+	if err != nil {
+		return err
+	}
+	p := planFor(rv.Type(), "csv", "db")
+	record := w.record[:0]
+	for _, name := range p.columns {
+		fi, _ := p.field(name)
+		field, err := csvField(rv.FieldByIndex(fi))
+		if err != nil {
+			return fmt.Errorf("null: CSV: column %s: %w", name, err)
+		}
+		record = append(record, field)
+	}
+	w.record = record
+	return w.Write(record)
+}
+
+// csvField, returns the CSV field of struct field v.
+func csvField(v reflect.Value) (String, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return String{}, nil
+		}
+		v = v.Elem()
+	}
+	var value interface{}
+	switch x := v.Interface().(type) {
+	case textValue:
+		if dv, err := x.Value(); err != nil || dv == nil {
+			return String{}, err
+		}
+		b, err := x.MarshalText()
+		return NewString(string(b)), err
+	case driver.Valuer:
+		dv, err := x.Value()
+		if err != nil || dv == nil {
+			return String{}, err
+		}
+		value = dv
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		return NewString(string(b)), err
+	default:
+		value = x
+	}
+	s, err := DefaultTimePolicy.convertString(value)
+	return NewString(s), err
+}
+
+// A CSVReader reads records from a CSV file. Unlike a csv.Reader it
+// reports unquoted fields equal to the Null text as NULL, quoted fields are
+// never NULL.
+//
+// Every line is a record, empty lines are records of a single empty field.
+// Errors are *csv.ParseError values.
+type CSVReader struct {
+	Comma rune   // field delimiter, set to ',' by NewCSVReader
+	Null  string // text of NULL fields, e.g. CSVNullEmpty or CSVNullEscape
+
+	r     *bufio.Reader
+	line  int // current line
+	field []byte
+
+	// ReadStruct state
+	header    []string
+	typ       reflect.Type
+	index     [][]int // field index of each column, nil if unmatched
+	unmatched *UnmatchedColumnsError
+}
+
+// NewCSVReader, returns a CSVReader that reads from r and treats unquoted
+// empty fields as NULL.
+func NewCSVReader(r io.Reader) *CSVReader {
+	return &CSVReader{Comma: ',', Null: CSVNullEmpty, r: bufio.NewReader(r)}
+}
+
+// Read, reads a record. NULL fields are returned as invalid Strings. If there
+// are no more records Read returns nil, io.EOF.
+func (r *CSVReader) Read() ([]String, error) {
+	r.line++
+	start, col := r.line, 0
+	parseError := func(err error) error {
+		return &csv.ParseError{StartLine: start, Line: r.line, Column: col, Err: err}
+	}
+	readRune := func() (rune, error) {
+		c, size, err := r.r.ReadRune()
+		col += size
+		return c, err
+	}
+
+	var record []String
+	for {
+		field := r.field[:0]
+		c, err := readRune()
+		if err == io.EOF && record == nil && col == 0 {
+			return nil, io.EOF
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		quoted := err == nil && c == '"'
+		if quoted {
+			for {
+				c, err = readRune()
+				if err == io.EOF {
+					return nil, parseError(csv.ErrQuote)
+				}
+				if err != nil {
+					return nil, err
+				}
+				if c == '"' {
+					if c, err = readRune(); err == nil && c == '"' {
+						field = append(field, '"')
+						continue
+					}
+					break
+				}
+				field = utf8.AppendRune(field, c)
+				if c == '\n' {
+					r.line++
+					col = 0
+				}
+			}
+			if err == nil && c == '\r' {
+				if c, err = readRune(); err == nil && c != '\n' {
+					return nil, parseError(csv.ErrQuote)
+				}
+			}
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			if err == nil && c != r.Comma && c != '\n' {
+				return nil, parseError(csv.ErrQuote)
+			}
+		} else {
+			for err == nil && c != r.Comma && c != '\n' {
+				if c == '"' {
+					return nil, parseError(csv.ErrBareQuote)
+				}
+				field = utf8.AppendRune(field, c)
+				c, err = readRune()
+			}
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			if err == nil && c == '\n' && len(field) > 0 && field[len(field)-1] == '\r' {
+				field = field[:len(field)-1]
+			}
+		}
+		r.field = field
+		if quoted || string(field) != r.Null {
+			record = append(record, NewString(string(field)))
+		} else {
+			record = append(record, String{})
+		}
+		if err == io.EOF || c == '\n' {
+			return record, nil
+		}
+	}
+}
+
+// ReadAll, reads all of the remaining records.
+func (r *CSVReader) ReadAll() ([][]String, error) {
+	var records [][]String
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+var errCSVDest = errors.New("null: ReadStruct: destination must be a non-nil pointer to a struct")
+
+// ReadStruct, reads a record into the struct pointed to by dst. The first
+// call reads the header, the record of column names, which are matched to
+// fields like WriteHeader names them. Fields may be of the types of this
+// package, pointers, sql.Scanner, encoding.TextUnmarshaler or basic types.
+// NULL is stored in pointer fields as nil and can not be stored in
+// encoding.TextUnmarshaler and basic types.
+//
+// Fields of the types of this package are parsed like UnmarshalCSV parses
+// them, so a Bool accepts the values of strconv.ParseBool, except that only
+// fields equal to the Null of the CSVReader are NULL, NullText is ignored.
+// Times are parsed in one of the TimeFormats of DefaultDecoder, RFC 3339 by
+// default, or as a database DATE or DATETIME, such as 2006-01-02 15:04:05,
+// like Scan parses them.
+//
+// If columns do not match a field an *UnmatchedColumnsError is returned after
+// the record is read. If there are no more records ReadStruct returns
+// io.EOF.
+func (r *CSVReader) ReadStruct(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errCSVDest
+	}
+	v = v.Elem()
+	if r.header == nil {
+		header, err := r.Read()
+		if err != nil {
+			return err
+		}
+		r.header = make([]string, len(header))
+		for i, name := range header {
+			r.header[i] = name.String
+		}
+	}
+	if t := v.Type(); t != r.typ {
+		r.mapColumns(t)
+	}
+
+	start := r.line + 1
+	record, err := r.Read()
+	if err != nil {
+		return err
+	}
+	if len(record) != len(r.header) {
+		return &csv.ParseError{StartLine: start, Line: r.line, Err: csv.ErrFieldCount}
+	}
+	for i, fi := range r.index {
+		if fi == nil {
+			continue
+		}
+		if err := setCSVField(v.FieldByIndex(fi), record[i]); err != nil {
+			return fmt.Errorf("null: CSV record on line %d: column %s: %w",
+				start, r.header[i], err)
+		}
+	}
+	if r.unmatched != nil {
+		return r.unmatched
+	}
+	return nil
+}
+
+// mapColumns, maps the columns of the header to the fields of struct type t.
+func (r *CSVReader) mapColumns(t reflect.Type) {
+	p := planFor(t, "csv", "db")
+	r.typ = t
+	r.index = make([][]int, len(r.header))
+	r.unmatched = nil
+	for i, name := range r.header {
+		if fi, ok := p.field(name); ok {
+			r.index[i] = fi
+			continue
+		}
+		if r.unmatched == nil {
+			r.unmatched = &UnmatchedColumnsError{Type: t.String()}
+		}
+		r.unmatched.Columns = append(r.unmatched.Columns, name)
+	}
+}
+
+// parseCSVTime, parses the CSV field s into Time t. It is parsed with the
+// TimeFormats of DefaultDecoder, RFC 3339 if empty, or else as a database
+// DATE or DATETIME, such as 2006-01-02 15:04:05, like Scan.
+func parseCSVTime(t *Time, s string) error {
+	err := t.parseText([]byte(s))
+	if err != nil && DefaultTimePolicy.ScanTime(s, t) == nil {
+		return nil
+	}
+	return err
+}
+
+// setCSVField, stores CSV field s in struct field v.
+func setCSVField(v reflect.Value, s String) error {
+	if v.Kind() == reflect.Ptr {
+		if !s.Valid {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	switch x := v.Addr().Interface().(type) {
+	case *String:
+		*x = s
+		return nil
+	case *UnescapedString:
+		*x = UnescapedString(s)
+		return nil
+	case *Time:
+		if !s.Valid {
+			x.Time, x.Valid = time.Time{}, false
+			return nil
+		}
+		return parseCSVTime(x, s.String)
+	case textParser:
+		// The Null of the CSVReader has been applied, so unlike
+		// UnmarshalCSV text equal to NullText is not NULL.
+		if !s.Valid {
+			return x.setValue(nil)
+		}
+		return x.parseText([]byte(s.String))
+	case sql.Scanner:
+		if !s.Valid {
+			return x.Scan(nil)
+		}
+		return x.Scan(s.String)
+	}
+	if !s.Valid {
+		return fmt.Errorf("cannot store NULL in type %s", v.Type())
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s.String))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s.String)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s.String)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseInt([]byte(s.String), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseUint([]byte(s.String), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := parseFloat([]byte(s.String), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot store CSV field in type %s: %w", v.Type(), ErrUnsupportedType)
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package null
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVWriter(t *testing.T) {
+	record := []String{
+		NewString(""), {}, NewString("a,b"), NewString(`q"`), NewString(`\N`),
+		NewString("x\ny"), NewString("NULL"),
+	}
+	tests := []struct {
+		null string
+		crlf bool
+		want string
+	}{
+		{CSVNullEmpty, false, `"",,"a,b","q""",\N,"x` + "\ny\",NULL\n"},
+		{CSVNullEscape, false, `,\N,"a,b","q""","\N","x` + "\ny\",NULL\n"},
+		{"NULL", true, `,NULL,"a,b","q""",\N,"x` + "\ny\",\"NULL\"\r\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		w := NewCSVWriter(&buf)
+		w.Null = test.null
+		w.UseCRLF = test.crlf
+		if err := w.WriteAll([][]String{record}); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("Null %q:\ngot:  %q\nwant: %q", test.null, buf.String(), test.want)
+		}
+
+		r := NewCSVReader(&buf)
+		r.Null = test.null
+		got, err := r.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || !reflect.DeepEqual(got[0], record) {
+			t.Errorf("Null %q: ReadAll = %+v want %+v", test.null, got, record)
+		}
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	tests := []struct {
+		in   string
+		null string
+		want [][]String
+	}{
+		{"", CSVNullEmpty, nil},
+		{"a,b\n", CSVNullEmpty, [][]String{{NewString("a"), NewString("b")}}},
+		{"a,b", CSVNullEmpty, [][]String{{NewString("a"), NewString("b")}}},
+		{"a,\r\n,b\r\n", CSVNullEmpty, [][]String{{NewString("a"), {}}, {{}, NewString("b")}}},
+		{`"",""""` + "\n", CSVNullEmpty, [][]String{{NewString(""), NewString(`"`)}}},
+		{"\n\n", CSVNullEmpty, [][]String{{{}}, {{}}}},
+		{"\n", CSVNullEscape, [][]String{{NewString("")}}},
+		{`\N,"\N",\n` + "\n", CSVNullEscape, [][]String{{{}, NewString(`\N`), NewString(`\n`)}}},
+		{"\"a\nb\",\"c\r\n\"\r\nd,", "x", [][]String{{NewString("a\nb"), NewString("c\r\n")}, {NewString("d"), NewString("")}}},
+		{"ä,€\n", CSVNullEmpty, [][]String{{NewString("ä"), NewString("€")}}},
+	}
+	for _, test := range tests {
+		r := NewCSVReader(strings.NewReader(test.in))
+		r.Null = test.null
+		got, err := r.ReadAll()
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ReadAll(%q) = %+v, %v want %+v", test.in, got, err, test.want)
+		}
+	}
+}
+
+func TestCSVReaderComma(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("a\tb,c\t\t\"d\te\"\n"))
+	r.Comma = '\t'
+	got, err := r.Read()
+	want := []String{NewString("a"), NewString("b,c"), {}, NewString("d\te")}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Read = %+v, %v want %+v", got, err, want)
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("Read = %v want %v", err, io.EOF)
+	}
+}
+
+func TestCSVReaderErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		line int
+		err  error
+	}{
+		{`a,b"c`, 1, csv.ErrBareQuote},
+		{"a\n\"b", 2, csv.ErrQuote},
+		{"a\n\"b\nc", 3, csv.ErrQuote},
+		{`"a"b`, 1, csv.ErrQuote},
+		{"\"a\"\rb", 1, csv.ErrQuote},
+	}
+	for _, test := range tests {
+		r := NewCSVReader(strings.NewReader(test.in))
+		_, err := r.ReadAll()
+		var perr *csv.ParseError
+		if !errors.As(err, &perr) || perr.Line != test.line || perr.Err != test.err {
+			t.Errorf("ReadAll(%q) = %v want line %d: %v", test.in, err, test.line, test.err)
+		}
+	}
+}
+
+type csvRecord struct {
+	ID      Int     `csv:"id"`
+	Big     Uint64  `db:"big"`
+	Name    String  `csv:"name" db:"other"`
+	Score   Float32 `csv:"score"`
+	Active  Bool
+	At      Time
+	Note    *string
+	Count   int
+	Skipped String `csv:"-"`
+}
+
+func TestCSVStruct(t *testing.T) {
+	note := "a \"note\""
+	at := time.Date(2006, 1, 2, 15, 4, 5, 123456789, time.UTC)
+	records := []csvRecord{
+		{
+			ID: NewInt(1), Big: NewUint64(math.MaxUint64), Name: NewString(""),
+			Score: NewFloat32(1.5), Active: NewBool(true), At: NewTime(at),
+			Note: &note, Count: -3,
+		},
+		{Count: 7},
+	}
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+	if err := w.WriteHeader(csvRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := range records {
+		if err := w.WriteStruct(&records[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,big,name,score,Active,At,Note,Count\n" +
+		`1,18446744073709551615,"",1.5,true,2006-01-02T15:04:05.123456789Z,"a ""note""",-3` + "\n" +
+		",,,,,,,7\n"
+	if buf.String() != want {
+		t.Errorf("WriteStruct:\ngot:  %q\nwant: %q", buf.String(), want)
+	}
+
+	r := NewCSVReader(&buf)
+	var got []csvRecord
+	for {
+		var rec csvRecord
+		err := r.ReadStruct(&rec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, rec)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Errorf("ReadStruct:\ngot:  %+v\nwant: %+v", got, records)
+	}
+}
+
+func TestCSVReadStructBool(t *testing.T) {
+	type row struct {
+		B Bool
+	}
+	r := NewCSVReader(strings.NewReader("B\n1\n0\nt\nf\nTRUE\n\nnull\n"))
+	for _, want := range []Bool{NewBool(true), NewBool(false), NewBool(true), NewBool(false), NewBool(true), {}} {
+		got := row{B: NewBool(true)}
+		if err := r.ReadStruct(&got); err != nil || got.B != want {
+			t.Errorf("ReadStruct = %+v, %v want %+v", got.B, err, want)
+		}
+	}
+	var got row
+	if err := r.ReadStruct(&got); err == nil || !strings.Contains(err.Error(), "line 8: column B") || got.B.Valid {
+		t.Errorf("ReadStruct(null) = %+v, %v want error", got.B, err)
+	}
+}
+
+func TestCSVReadStructNullEscape(t *testing.T) {
+	defer func(d Decoder) { DefaultDecoder = d }(DefaultDecoder)
+
+	type row struct {
+		I Int
+		S String
+		T Time
+	}
+	r := NewCSVReader(strings.NewReader("I,S,T\n" +
+		"\\N,\\N,\\N\n" +
+		"1,,2006-01-02 15:04:05\n" +
+		"2,x,2006-01-02T15:04:05Z\n" +
+		"3,x,02/01/2006\n" +
+		",x,2006-01-02\n" +
+		"4,x,\n"))
+	r.Null = CSVNullEscape
+	at := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	want := []row{
+		{},
+		{NewInt(1), NewString(""), NewTime(at)},
+		{NewInt(2), NewString("x"), NewTime(at)},
+	}
+	for _, w := range want {
+		got := row{I: NewInt(9), T: NewTime(at)}
+		if err := r.ReadStruct(&got); err != nil || !reflect.DeepEqual(got, w) {
+			t.Errorf("ReadStruct = %+v, %v want %+v", got, err, w)
+		}
+	}
+	DefaultDecoder.TimeFormats = []string{"02/01/2006"}
+	var got row
+	if err := r.ReadStruct(&got); err != nil || got.T != NewTime(time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ReadStruct(TimeFormats) = %+v, %v", got, err)
+	}
+	for _, column := range []string{"I", "T"} {
+		if err := r.ReadStruct(&got); err == nil || !strings.Contains(err.Error(), "column "+column) {
+			t.Errorf("ReadStruct(empty %s) = %+v, %v want error", column, got, err)
+		}
+	}
+}
+
+func TestCSVReadStructErrors(t *testing.T) {
+	type partial struct {
+		ID    Int `csv:"id"`
+		Count int
+	}
+	r := NewCSVReader(strings.NewReader("id,extra,count\n1,x,2\n3,y\n,z,\n"))
+	var p partial
+	var uerr *UnmatchedColumnsError
+	if err := r.ReadStruct(&p); !errors.As(err, &uerr) || len(uerr.Columns) != 1 || uerr.Columns[0] != "extra" {
+		t.Errorf("ReadStruct = %v want unmatched column extra", err)
+	}
+	if p.ID != NewInt(1) || p.Count != 2 {
+		t.Errorf("ReadStruct = %+v", p)
+	}
+	var perr *csv.ParseError
+	if err := r.ReadStruct(&p); !errors.As(err, &perr) || perr.Err != csv.ErrFieldCount || perr.StartLine != 3 {
+		t.Errorf("ReadStruct = %v want %v on line 3", err, csv.ErrFieldCount)
+	}
+	if err := r.ReadStruct(&p); err == nil || !strings.Contains(err.Error(), "line 4: column count") {
+		t.Errorf("ReadStruct(NULL into int) = %v", err)
+	}
+	if err := r.ReadStruct(p); err != errCSVDest {
+		t.Errorf("ReadStruct(%T) = %v want %v", p, err, errCSVDest)
+	}
+
+	r = NewCSVReader(strings.NewReader("id\nabc\n"))
+	if err := r.ReadStruct(&p); !errors.Is(err, ErrSyntax) {
+		t.Errorf("ReadStruct = %v want %v", err, ErrSyntax)
+	}
+
+	w := NewCSVWriter(io.Discard)
+	if err := w.WriteStruct(1); err != errCSVStruct {
+		t.Errorf("WriteStruct(1) = %v want %v", err, errCSVStruct)
+	}
+	if err := w.WriteStruct(struct{ C chan int }{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("WriteStruct(chan) = %v want %v", err, ErrUnsupportedType)
+	}
+}
+
+func TestMarshalCSV(t *testing.T) {
+	values := []interface {
+		MarshalCSV() (string, error)
+	}{
+		NewInt(-1), NewUint64(math.MaxUint64), NewFloat64(0.5), NewFloat32(2),
+		NewString("s"), NewUnescapedString("<u>"), NewBool(false),
+		NewTime(time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)),
+	}
+	want := []string{"-1", "18446744073709551615", "0.5", "2", "s", "<u>", "false", "2006-01-02T00:00:00Z"}
+	for i, v := range values {
+		s, err := v.MarshalCSV()
+		if err != nil || s != want[i] {
+			t.Errorf("%T.MarshalCSV() = %q, %v want %q", v, s, err, want[i])
+		}
+		p := reflect.New(reflect.TypeOf(v))
+		u := p.Interface().(interface{ UnmarshalCSV(string) error })
+		if err := u.UnmarshalCSV(s); err != nil || p.Elem().Interface() != v {
+			t.Errorf("%T.UnmarshalCSV(%q) = %v, %v", v, s, p.Elem().Interface(), err)
+		}
+		if err := u.UnmarshalCSV(NullText); err != nil || !reflect.ValueOf(p.Elem().Interface()).IsZero() {
+			t.Errorf("%T.UnmarshalCSV(NullText) = %v, %v", v, p.Elem().Interface(), err)
+		}
+	}
+	if s, err := (Int{}).MarshalCSV(); err != nil || s != NullText {
+		t.Errorf("Int{}.MarshalCSV() = %q, %v want %q", s, err, NullText)
+	}
+}
@@ -0,0 +1,283 @@
+package null
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// A DecodeMode selects the JSON values accepted when unmarshaling.
+//
+// In every mode JSON null unmarshals as NULL.
+type DecodeMode int
+
+const (
+	// DecodeDefault, accepts the values historically accepted by
+	// UnmarshalJSON. Numbers may be quoted, booleans must be true or false
+	// and may be quoted, strings must be JSON strings and times must match
+	// the TimeFormats of the Decoder.
+	DecodeDefault DecodeMode = iota
+
+	// DecodeStrict, only accepts the native JSON kind of each type: numbers
+	// for Int, Float64 and Float32, strings for String, true and false for
+	// Bool, and strings for Time, or numbers for the Unix TimeFormats. The
+	// quoted "true" and "false" written by Bool.MarshalJSON are accepted as
+	// well, so that every type can unmarshal its own output.
+	DecodeStrict
+
+	// DecodeLenient, accepts everything DecodeDefault does and in addition:
+	// whitespace around quoted values is trimmed, the empty string, after
+	// trimming, unmarshals as NULL, booleans may be any value accepted by
+	// strconv.ParseBool, quoted or not, such as 1, "0" or "TRUE", and
+	// numbers and booleans are accepted as a String.
+	DecodeLenient
+)
+
+// A Decoder controls how values are unmarshaled from JSON.
+//
+// The zero value of Decoder matches the behavior of UnmarshalJSON prior to
+// the introduction of Decoder.
+type Decoder struct {
+	// TimeFormats, are the formats accepted when unmarshaling a Time. Each
+	// format is either a time layout, as accepted by time.Parse, or one of
+	// the Unix TimeFormat constants. Formats are tried in order and the
+	// first one that succeeds is used.
+	//
+	// If empty, times must be quoted RFC 3339 strings.
+	TimeFormats []string
+
+	// Mode, selects the JSON values accepted, see DecodeMode.
+	Mode DecodeMode
+}
+
+// DefaultDecoder is the Decoder used by UnmarshalJSON.
+//
+// DefaultDecoder is not safe to modify while values are being unmarshaled and
+// should only be changed during program initialization. Use the options
+// returned by Decoder.UnmarshalOptions to select a Decoder per call.
+var DefaultDecoder Decoder
+
+// UnmarshalTime, unmarshals the JSON data into Time t using the time formats
+// of Decoder d.
+func (d *Decoder) UnmarshalTime(data []byte, t *Time) error {
+	v, isNull, err := d.scalar(data, true)
+	if err != nil || isNull {
+		t.Time, t.Valid = time.Time{}, false
+		return unmarshalError("null.Time", data, err)
+	}
+	t.Time, err = d.parseTime(v)
+	t.Valid = (err == nil)
+	return unmarshalError("null.Time", data, err)
+}
+
+// isJSONString, returns if data is a JSON string.
+func isJSONString(data []byte) bool {
+	return len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"'
+}
+
+// trimSpace, trims the whitespace from both ends of s.
+func trimSpace(s []byte) []byte {
+	for len(s) > 0 && isSpace(s[0]) {
+		s = s[1:]
+	}
+	for len(s) > 0 && isSpace(s[len(s)-1]) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// scalar, applies the rules of the Mode of Decoder d that are common to all
+// types to the JSON value data. It reports whether data is NULL, and
+// otherwise returns data with, in lenient mode, the whitespace inside of
+// quoted values trimmed. Quoted values are only allowed if quoted is set or
+// the mode is not strict.
+func (d *Decoder) scalar(data []byte, quoted bool) ([]byte, bool, error) {
+	if null(data) {
+		return data, true, nil
+	}
+	if len(data) == 0 {
+		return data, false, ErrSyntax
+	}
+	switch d.Mode {
+	case DecodeStrict:
+		if isJSONString(data) && !quoted {
+			return data, false, ErrUnsupportedType
+		}
+	case DecodeLenient:
+		if isJSONString(data) {
+			s := trimSpace(data[1 : len(data)-1])
+			if len(s) == 0 {
+				return data, true, nil
+			}
+			if len(s) != len(data)-2 {
+				// Requote so that types that require a JSON string,
+				// such as String and Time, still see one.
+				b := make([]byte, 0, len(s)+2)
+				b = append(append(append(b, '"'), s...), '"')
+				data = b
+			}
+		}
+	}
+	return data, false, nil
+}
+
+// unmarshalError, returns err, if not nil, as an *UnmarshalError for the
+// JSON data and type typ.
+func unmarshalError(typ string, data []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	return newUnmarshalError(typ, data, err)
+}
+
+// UnmarshalInt, unmarshals the JSON data into Int i using the Mode of
+// Decoder d.
+func (d *Decoder) UnmarshalInt(data []byte, i *Int) error {
+	v, isNull, err := d.scalar(data, false)
+	if err != nil || isNull {
+		i.Int, i.Valid = 0, false
+		return unmarshalError("null.Int", data, err)
+	}
+	n, err := parseInt(unquote(v), strconv.IntSize)
+	if err == nil {
+		i.Int = int(n)
+	}
+	i.Valid = (err == nil)
+	return unmarshalError("null.Int", data, err)
+}
+
+// UnmarshalUint64, unmarshals the JSON data into Uint64 u using the Mode of
+// Decoder d.
+func (d *Decoder) UnmarshalUint64(data []byte, u *Uint64) error {
+	v, isNull, err := d.scalar(data, false)
+	if err != nil || isNull {
+		u.Uint64, u.Valid = 0, false
+		return unmarshalError("null.Uint64", data, err)
+	}
+	n, err := parseUint(unquote(v), 64)
+	if err == nil {
+		u.Uint64 = n
+	}
+	u.Valid = (err == nil)
+	return unmarshalError("null.Uint64", data, err)
+}
+
+// UnmarshalFloat64, unmarshals the JSON data into Float64 f using the Mode
+// of Decoder d.
+func (d *Decoder) UnmarshalFloat64(data []byte, f *Float64) error {
+	v, isNull, err := d.scalar(data, false)
+	if err != nil || isNull {
+		f.Float64, f.Valid = 0, false
+		return unmarshalError("null.Float64", data, err)
+	}
+	f.Float64, err = parseFloat(unquote(v), 64)
+	f.Valid = (err == nil)
+	return unmarshalError("null.Float64", data, err)
+}
+
+// UnmarshalFloat32, unmarshals the JSON data into Float32 f using the Mode
+// of Decoder d.
+func (d *Decoder) UnmarshalFloat32(data []byte, f *Float32) error {
+	v, isNull, err := d.scalar(data, false)
+	if err != nil || isNull {
+		f.Float32, f.Valid = 0, false
+		return unmarshalError("null.Float32", data, err)
+	}
+	var ff float64
+	ff, err = parseFloat(unquote(v), 32)
+	f.Valid = (err == nil)
+	f.Float32 = float32(ff)
+	return unmarshalError("null.Float32", data, err)
+}
+
+// UnmarshalString, unmarshals the JSON data into String s using the Mode of
+// Decoder d.
+func (d *Decoder) UnmarshalString(data []byte, s *String) error {
+	v, isNull, err := d.scalar(data, true)
+	if err != nil || isNull {
+		s.String, s.Valid = "", false
+		return unmarshalError("null.String", data, err)
+	}
+	if d.Mode == DecodeLenient && !isJSONString(v) {
+		switch c := v[0]; {
+		case c == '-' || ('0' <= c && c <= '9'):
+			if _, err := parseFloat(v, 64); err == nil {
+				s.String, s.Valid = string(v), true
+				return nil
+			}
+		case string(v) == "true" || string(v) == "false":
+			s.String, s.Valid = string(v), true
+			return nil
+		}
+	}
+	s.String, err = unmarshalString(v)
+	s.Valid = (err == nil)
+	return unmarshalError("null.String", data, err)
+}
+
+// UnmarshalBool, unmarshals the JSON data into Bool b using the Mode of
+// Decoder d.
+func (d *Decoder) UnmarshalBool(data []byte, b *Bool) error {
+	// Bool.MarshalJSON writes quoted booleans, which are accepted in every
+	// mode so that the output of MarshalJSON can always be unmarshaled.
+	v, isNull, err := d.scalar(data, quotedBool(data))
+	if err != nil || isNull {
+		b.Bool, b.Valid = false, false
+		return unmarshalError("null.Bool", data, err)
+	}
+	switch s := string(unquote(v)); s {
+	case "true":
+		b.Bool, b.Valid = true, true
+	case "false":
+		b.Bool, b.Valid = false, true
+	default:
+		if d.Mode == DecodeLenient {
+			if v, perr := strconv.ParseBool(s); perr == nil {
+				b.Bool, b.Valid = v, true
+				return nil
+			}
+		}
+		b.Bool, b.Valid = false, false
+		return newUnmarshalError("null.Bool", data, ErrSyntax)
+	}
+	return nil
+}
+
+// quotedBool, reports if data is "true" or "false", a Bool as encoded by
+// MarshalJSON.
+func quotedBool(data []byte) bool {
+	return string(data) == `"true"` || string(data) == `"false"`
+}
+
+// Unmarshal, unmarshals the JSON data into v, which must be a pointer to one
+// of the types of this package, using the Mode of Decoder d.
+//
+// To unmarshal structs with Decoder d use encoding/json/v2 with the options
+// returned by UnmarshalOptions, which requires GOEXPERIMENT=jsonv2. The Mode
+// of DefaultDecoder, which is used by UnmarshalJSON, applies to every call
+// in the process.
+func (d *Decoder) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Int:
+		return d.UnmarshalInt(data, v)
+	case *Uint64:
+		return d.UnmarshalUint64(data, v)
+	case *Float64:
+		return d.UnmarshalFloat64(data, v)
+	case *Float32:
+		return d.UnmarshalFloat32(data, v)
+	case *String:
+		return d.UnmarshalString(data, v)
+	case *UnescapedString:
+		return d.UnmarshalString(data, (*String)(v))
+	case *Bool:
+		return d.UnmarshalBool(data, v)
+	case *Time:
+		return d.UnmarshalTime(data, v)
+	}
+	return newUnmarshalError(fmt.Sprintf("%T", v), data, ErrUnsupportedType)
+}
@@ -0,0 +1,194 @@
+package null
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var decodeModeTests = []struct {
+	in      string
+	out     interface{}
+	strict  bool // accepted in DecodeStrict mode
+	def     bool // accepted in DecodeDefault mode
+	lenient bool // accepted in DecodeLenient mode
+}{
+	{`null`, Int{}, true, true, true},
+	{`42`, NewInt(42), true, true, true},
+	{`"42"`, NewInt(42), false, true, true},
+	{`" 42 "`, NewInt(42), false, false, true},
+	{`""`, Int{}, false, false, true},
+	{`"  "`, Int{}, false, false, true},
+	{`1.5`, NewInt(0), false, false, false},
+
+	{`1.5`, NewFloat64(1.5), true, true, true},
+	{`"1.5"`, NewFloat64(1.5), false, true, true},
+	{`"  1.5 "`, NewFloat64(1.5), false, false, true},
+	{`""`, Float64{}, false, false, true},
+	{`true`, Float64{}, false, false, false},
+
+	{`1.5`, NewFloat32(1.5), true, true, true},
+	{`"1.5"`, NewFloat32(1.5), false, true, true},
+	{`""`, Float32{}, false, false, true},
+
+	{`"a"`, NewString("a"), true, true, true},
+	{`42`, NewString("42"), false, false, true},
+	{`-1.5e3`, NewString("-1.5e3"), false, false, true},
+	{`true`, NewString("true"), false, false, true},
+	{`{}`, String{}, false, false, false},
+
+	{`true`, NewBool(true), true, true, true},
+	{`"true"`, NewBool(true), true, true, true},
+	{`"false"`, NewBool(false), true, true, true},
+	{`"True"`, NewBool(true), false, false, true},
+	{`1`, NewBool(true), false, false, true},
+	{`"0"`, NewBool(false), false, false, true},
+	{`"TRUE"`, NewBool(true), false, false, true},
+	{`" t "`, NewBool(true), false, false, true},
+	{`""`, Bool{}, false, false, true},
+	{`"yes"`, Bool{}, false, false, false},
+
+	{`"2017-10-20T23:04:56Z"`, NewTime(time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC)), true, true, true},
+	{`" 2017-10-20T23:04:56Z "`, NewTime(time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC)), false, false, true},
+	{`""`, Time{}, false, false, true},
+	{`1508540696`, Time{}, false, false, false},
+}
+
+func TestDecodeModes(t *testing.T) {
+	for _, test := range decodeModeTests {
+		for _, mode := range []struct {
+			mode DecodeMode
+			ok   bool
+		}{
+			{DecodeStrict, test.strict},
+			{DecodeDefault, test.def},
+			{DecodeLenient, test.lenient},
+		} {
+			d := Decoder{Mode: mode.mode}
+			v := newTextUnmarshaler(test.out)
+			err := d.Unmarshal([]byte(test.in), v)
+			if mode.ok != (err == nil) {
+				t.Errorf("Mode %d: Unmarshal(%s, %T): got error %v, want ok %t",
+					mode.mode, test.in, test.out, err, mode.ok)
+				continue
+			}
+			if err == nil && !textEqual(v, test.out) {
+				t.Errorf("Mode %d: Unmarshal(%s, %T) = %+v, want %+v",
+					mode.mode, test.in, test.out, v, test.out)
+			}
+		}
+	}
+}
+
+func TestDecodeLenientString(t *testing.T) {
+	tests := []struct {
+		in      string
+		def     String
+		lenient String
+	}{
+		{`" a "`, NewString(" a "), NewString("a")},
+		{`""`, NewString(""), String{}},
+		{`"  "`, NewString("  "), String{}},
+	}
+	for _, test := range tests {
+		for _, mode := range []DecodeMode{DecodeStrict, DecodeDefault, DecodeLenient} {
+			want := test.def
+			if mode == DecodeLenient {
+				want = test.lenient
+			}
+			var v String
+			d := Decoder{Mode: mode}
+			if err := d.UnmarshalString([]byte(test.in), &v); err != nil || v != want {
+				t.Errorf("Mode %d: UnmarshalString(%s) = %+v, %v want %+v", mode, test.in, v, err, want)
+			}
+		}
+	}
+}
+
+func TestDecodeStrictUnixTime(t *testing.T) {
+	d := Decoder{Mode: DecodeStrict, TimeFormats: []string{TimeFormatUnix}}
+	var v Time
+	if err := d.UnmarshalTime([]byte(`1508540696`), &v); err != nil || !v.Valid {
+		t.Errorf("UnmarshalTime(1508540696) = %+v, %v", v, err)
+	}
+	if err := d.UnmarshalTime([]byte(`"1508540696"`), &v); err == nil {
+		t.Errorf("UnmarshalTime(%s): expected error in strict mode", `"1508540696"`)
+	}
+	d.Mode = DecodeDefault
+	if err := d.UnmarshalTime([]byte(`"1508540696"`), &v); err != nil || !v.Valid {
+		t.Errorf("UnmarshalTime(%s) = %+v, %v", `"1508540696"`, v, err)
+	}
+}
+
+func TestDefaultDecoderMode(t *testing.T) {
+	defer func(d Decoder) { DefaultDecoder = d }(DefaultDecoder)
+
+	var v struct {
+		I Int
+		S String
+		B Bool
+	}
+	const in = `{"I":"1","S":"","B":"1"}`
+	if err := json.Unmarshal([]byte(in), &v); err == nil {
+		t.Errorf("Unmarshal(%s): expected error in default mode", in)
+	}
+	DefaultDecoder.Mode = DecodeLenient
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.I != NewInt(1) || v.S.Valid || v.B != NewBool(true) {
+		t.Errorf("Unmarshal(%s) = %+v", in, v)
+	}
+	DefaultDecoder.Mode = DecodeStrict
+	if err := json.Unmarshal([]byte(`{"I":"1"}`), &v); err == nil {
+		t.Errorf("Unmarshal(%s): expected error in strict mode", `{"I":"1"}`)
+	}
+	if err := (&Decoder{}).Unmarshal([]byte("1"), new(int)); err == nil {
+		t.Error("Unmarshal(*int): expected error")
+	}
+}
+
+func TestDecodeStrictRoundTrip(t *testing.T) {
+	defer func(d Decoder) { DefaultDecoder = d }(DefaultDecoder)
+	DefaultDecoder.Mode = DecodeStrict
+
+	type values struct {
+		Int       Int
+		Uint64    Uint64
+		Float64   Float64
+		Float32   Float32
+		String    String
+		Unescaped UnescapedString
+		Bool      Bool
+		False     Bool
+		Time      Time
+	}
+	tests := []values{
+		{
+			Int:       NewInt(-1),
+			Uint64:    NewUint64(1 << 63),
+			Float64:   NewFloat64(1.5),
+			Float32:   NewFloat32(-2.5),
+			String:    NewString("<a>"),
+			Unescaped: NewUnescapedString("<b>"),
+			Bool:      NewBool(true),
+			False:     NewBool(false),
+			Time:      NewTime(time.Date(2017, 10, 20, 23, 4, 56, 123, time.UTC)),
+		},
+		{},
+	}
+	for _, want := range tests {
+		b, err := json.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got values
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Errorf("Unmarshal(%s) in strict mode: %v", b, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Unmarshal(%s) in strict mode = %+v want %+v", b, got, want)
+		}
+	}
+}
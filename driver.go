@@ -0,0 +1,165 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Converter, is a driver.ValueConverter for drivers that accept uint64
+// values, such as github.com/go-sql-driver/mysql. Unlike
+// driver.DefaultParameterConverter it converts a valid Uint64, and unsigned
+// integers greater than math.MaxInt64, to a uint64 instead of rejecting
+// them or converting them to a string. All other values are converted by
+// driver.DefaultParameterConverter.
+var Converter driver.ValueConverter = converter{}
+
+type converter struct{}
+
+func (converter) ConvertValue(v interface{}) (driver.Value, error) {
+	switch u := v.(type) {
+	case Uint64:
+		if !u.Valid {
+			return nil, nil
+		}
+		return u.Uint64, nil
+	case *Uint64:
+		if u == nil || !u.Valid {
+			return nil, nil
+		}
+		return u.Uint64, nil
+	case uint64:
+		return u, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		if _, ok := v.(driver.Valuer); !ok {
+			return rv.Uint(), nil
+		}
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// CheckNamedValue, may be called by the CheckNamedValue method of the
+// driver.NamedValueChecker of a driver that accepts uint64 values. It converts
+// the value of nv with Converter if it is a Uint64 or an unsigned integer, and
+// otherwise returns driver.ErrSkip so that the value is converted as usual.
+func CheckNamedValue(nv *driver.NamedValue) error {
+	switch nv.Value.(type) {
+	case Uint64, *Uint64, uint64, uint:
+	default:
+		return driver.ErrSkip
+	}
+	v, err := Converter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	nv.Value = v
+	return nil
+}
+
+// The types of this package returned by ScanType.
+var (
+	intType     = reflect.TypeOf(Int{})
+	uint64Type  = reflect.TypeOf(Uint64{})
+	float64Type = reflect.TypeOf(Float64{})
+	float32Type = reflect.TypeOf(Float32{})
+	stringType  = reflect.TypeOf(String{})
+	boolType    = reflect.TypeOf(Bool{})
+	timeType    = reflect.TypeOf(Time{})
+)
+
+// ScanType, returns the type of this package that a column with the database
+// type name databaseTypeName, as returned by sql.ColumnType.DatabaseTypeName,
+// should be scanned into without loss, e.g. Uint64 for "UNSIGNED BIGINT" or
+// "BIGINT UNSIGNED" and Time for "DATETIME". ScanType is the type reported
+// by the driver, which may be nil, and is used for columns the driver reports
+// as unsigned and for unrecognized names. DECIMAL, NUMERIC and unrecognized
+// columns are scanned into a String.
+func ScanType(databaseTypeName string, scanType reflect.Type) reflect.Type {
+	name := strings.ToUpper(databaseTypeName)
+	unsigned := strings.Contains(name, "UNSIGNED")
+	if unsigned {
+		name = strings.Replace(name, "UNSIGNED", "", 1)
+	}
+	if i := strings.IndexByte(name, '('); i != -1 {
+		// Remove the display width or precision, e.g. BIGINT(20).
+		if j := strings.IndexByte(name[i:], ')'); j != -1 {
+			name = name[:i] + name[i+j+1:]
+		}
+	}
+	name = strings.TrimSpace(name)
+	for scanType != nil && scanType.Kind() == reflect.Ptr {
+		scanType = scanType.Elem()
+	}
+	if scanType != nil {
+		switch scanType.Kind() {
+		case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+			// Drivers that do not include UNSIGNED in the type name
+			// may still report the column as unsigned.
+			return uint64Type
+		}
+	}
+	switch name {
+	case "BIGINT", "INT8", "BIGSERIAL":
+		if unsigned {
+			return uint64Type
+		}
+		return intType
+	case "INT", "INTEGER", "MEDIUMINT", "SMALLINT", "TINYINT", "INT2", "INT4",
+		"SERIAL", "SMALLSERIAL", "YEAR":
+		if unsigned && strings.HasPrefix(name, "INT") && intType.Size() < 8 {
+			return uint64Type
+		}
+		return intType
+	case "FLOAT4":
+		return float32Type
+	case "FLOAT", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "REAL":
+		return float64Type
+	case "BOOL", "BOOLEAN":
+		return boolType
+	case "DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		return timeType
+	case "DECIMAL", "NUMERIC":
+		return stringType
+	}
+	if scanType == nil {
+		return stringType
+	}
+	switch scanType {
+	case reflect.TypeOf(sql.NullInt64{}), reflect.TypeOf(sql.NullInt32{}), reflect.TypeOf(sql.NullInt16{}),
+		reflect.TypeOf(sql.NullByte{}):
+		return intType
+	case reflect.TypeOf(sql.NullFloat64{}):
+		return float64Type
+	case reflect.TypeOf(sql.NullBool{}):
+		return boolType
+	case reflect.TypeOf(sql.NullTime{}), reflect.TypeOf(time.Time{}):
+		return timeType
+	}
+	switch scanType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16:
+		return intType
+	case reflect.Uint32:
+		if intType.Size() < 8 {
+			return uint64Type
+		}
+		return intType
+	case reflect.Float32:
+		return float32Type
+	case reflect.Float64:
+		return float64Type
+	case reflect.Bool:
+		return boolType
+	}
+	return stringType
+}
+
+// ColumnScanType, returns the ScanType of column ct.
+func ColumnScanType(ct *sql.ColumnType) reflect.Type {
+	return ScanType(ct.DatabaseTypeName(), ct.ScanType())
+}
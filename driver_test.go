@@ -0,0 +1,187 @@
+package null
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var uint64Tests = []struct {
+	in    interface{}
+	out   uint64
+	value driver.Value
+}{
+	{int64(0), 0, int64(0)},
+	{int64(math.MaxInt64), math.MaxInt64, int64(math.MaxInt64)},
+	{uint64(math.MaxInt64 + 1), math.MaxInt64 + 1, "9223372036854775808"},
+	{uint64(math.MaxUint64), math.MaxUint64, "18446744073709551615"},
+	{[]byte("18446744073709551615"), math.MaxUint64, "18446744073709551615"},
+	{"4294967295", math.MaxUint32, int64(math.MaxUint32)},
+	{float64(1 << 40), 1 << 40, int64(1 << 40)},
+}
+
+func TestUint64ScanValue(t *testing.T) {
+	for _, test := range uint64Tests {
+		var u Uint64
+		if err := u.Scan(test.in); err != nil || !u.Valid || u.Uint64 != test.out {
+			t.Errorf("Uint64.Scan(%#v) = %+v, %v want %d", test.in, u, err, test.out)
+			continue
+		}
+		v, err := u.Value()
+		if err != nil || v != test.value {
+			t.Errorf("Uint64.Value(%d) = %#v, %v want %#v", u.Uint64, v, err, test.value)
+		}
+		if !driver.IsValue(v) {
+			t.Errorf("Uint64.Value(%d) = %#v: not a driver.Value", u.Uint64, v)
+		}
+
+		// Read the value back as it would be by the database.
+		var back Uint64
+		if err := back.Scan(v); err != nil || back != u {
+			t.Errorf("Uint64.Scan(%#v) = %+v, %v want %+v", v, back, err, u)
+		}
+	}
+}
+
+func TestUint64ScanErrors(t *testing.T) {
+	for _, in := range []interface{}{int64(-1), "-1", "18446744073709551616", float64(-1), 1.5, true} {
+		var u Uint64
+		err := u.Scan(in)
+		var serr *ScanError
+		if !errors.As(err, &serr) || u.Valid {
+			t.Errorf("Uint64.Scan(%#v) = %+v, %v: want *ScanError", in, u, err)
+		}
+	}
+	var u Uint64
+	if err := u.Scan(nil); err != nil || u.Valid {
+		t.Errorf("Uint64.Scan(nil) = %+v, %v: want NULL", u, err)
+	}
+}
+
+func TestUint64JSON(t *testing.T) {
+	tests := []struct {
+		in  Uint64
+		out string
+	}{
+		{NewUint64(math.MaxUint64), "18446744073709551615"},
+		{NewUint64(0), "0"},
+		{Uint64{}, "null"},
+	}
+	for _, test := range tests {
+		b, err := test.in.MarshalJSON()
+		if err != nil || string(b) != test.out {
+			t.Errorf("MarshalJSON(%+v) = %s, %v want %s", test.in, b, err, test.out)
+		}
+		var u Uint64
+		if err := u.UnmarshalJSON(b); err != nil || u != test.in {
+			t.Errorf("UnmarshalJSON(%s) = %+v, %v want %+v", b, u, err, test.in)
+		}
+		text, err := test.in.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		u = Uint64{}
+		if err := u.UnmarshalText(text); err != nil || u != test.in {
+			t.Errorf("UnmarshalText(%q) = %+v, %v want %+v", text, u, err, test.in)
+		}
+	}
+	var u Uint64
+	if err := u.UnmarshalJSON([]byte(`-1`)); err == nil || u.Valid {
+		t.Errorf("UnmarshalJSON(-1) = %+v, %v: want error", u, err)
+	}
+	if err := u.UnmarshalJSON([]byte(`"18446744073709551615"`)); err != nil || u.Uint64 != math.MaxUint64 {
+		t.Errorf("UnmarshalJSON(quoted) = %+v, %v", u, err)
+	}
+}
+
+func TestUint64Ptr(t *testing.T) {
+	if p := (Uint64{}).Ptr(); p != nil {
+		t.Errorf("Ptr() = %v want nil", p)
+	}
+	n := uint64(math.MaxUint64)
+	if u := PtrUint64(&n); !u.Valid || *u.Ptr() != n {
+		t.Errorf("PtrUint64(%d) = %+v", n, u)
+	}
+	if u := PtrUint64(nil); u.Valid {
+		t.Errorf("PtrUint64(nil) = %+v", u)
+	}
+}
+
+type namedUint64 uint64
+
+func TestConverter(t *testing.T) {
+	tests := []struct {
+		in  interface{}
+		out driver.Value
+	}{
+		{NewUint64(math.MaxUint64), uint64(math.MaxUint64)},
+		{&Uint64{Uint64: 1, Valid: true}, uint64(1)},
+		{Uint64{}, nil},
+		{(*Uint64)(nil), nil},
+		{uint64(math.MaxUint64), uint64(math.MaxUint64)},
+		{namedUint64(math.MaxUint64), uint64(math.MaxUint64)},
+		{uint32(7), int64(7)},
+		{NewInt(-1), int64(-1)},
+		{NewString("a"), "a"},
+		{String{}, nil},
+		{"b", "b"},
+	}
+	for _, test := range tests {
+		out, err := Converter.ConvertValue(test.in)
+		if err != nil || out != test.out {
+			t.Errorf("ConvertValue(%#v) = %#v, %v want %#v", test.in, out, err, test.out)
+		}
+	}
+	if _, err := Converter.ConvertValue(struct{}{}); err == nil {
+		t.Error("ConvertValue(struct{}{}): expected error")
+	}
+}
+
+func TestCheckNamedValue(t *testing.T) {
+	nv := driver.NamedValue{Ordinal: 1, Value: NewUint64(math.MaxUint64)}
+	if err := CheckNamedValue(&nv); err != nil || nv.Value != uint64(math.MaxUint64) {
+		t.Errorf("CheckNamedValue(Uint64) = %#v, %v", nv.Value, err)
+	}
+	nv = driver.NamedValue{Ordinal: 1, Value: NewInt(1)}
+	if err := CheckNamedValue(&nv); err != driver.ErrSkip {
+		t.Errorf("CheckNamedValue(Int) = %v want driver.ErrSkip", err)
+	}
+}
+
+func TestScanType(t *testing.T) {
+	tests := []struct {
+		name     string
+		scanType reflect.Type
+		want     interface{}
+	}{
+		{"BIGINT UNSIGNED", nil, Uint64{}},
+		{"UNSIGNED BIGINT", nil, Uint64{}},
+		{"bigint(20) unsigned", nil, Uint64{}},
+		{"BIGINT", reflect.TypeOf(uint64(0)), Uint64{}},
+		{"BIGINT", reflect.TypeOf(sql.NullInt64{}), Int{}},
+		{"INT", nil, Int{}},
+		{"int4", nil, Int{}},
+		{"DOUBLE", nil, Float64{}},
+		{"FLOAT4", nil, Float32{}},
+		{"DECIMAL", reflect.TypeOf(sql.RawBytes{}), String{}},
+		{"VARCHAR", reflect.TypeOf(sql.NullString{}), String{}},
+		{"BOOLEAN", nil, Bool{}},
+		{"DATETIME", reflect.TypeOf(sql.NullTime{}), Time{}},
+		{"TIMESTAMPTZ", nil, Time{}},
+		{"", reflect.TypeOf(time.Time{}), Time{}},
+		{"", reflect.TypeOf(int32(0)), Int{}},
+		{"", reflect.TypeOf(new(float32)), Float32{}},
+		{"", reflect.TypeOf(sql.NullBool{}), Bool{}},
+		{"", nil, String{}},
+	}
+	for _, test := range tests {
+		got := ScanType(test.name, test.scanType)
+		if want := reflect.TypeOf(test.want); got != want {
+			t.Errorf("ScanType(%q, %v) = %v want %v", test.name, test.scanType, got, want)
+		}
+	}
+}
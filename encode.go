@@ -0,0 +1,82 @@
+package null
+
+import "time"
+
+// An Encoder controls the escaping applied to strings when they are marshaled
+// into JSON.
+//
+// The zero value of Encoder only performs the escaping required by JSON.
+//
+// Note that json.Marshal applies its own HTML escaping to the output of
+// MarshalJSON, disabling EscapeHTML only has an effect when values are
+// marshaled directly or with a json.Encoder that has SetEscapeHTML(false).
+type Encoder struct {
+	// EscapeHTML, escapes the HTML characters <, > and & as \u003c, \u003e
+	// and \u0026 so that the output can be safely embedded inside of HTML
+	// <script> tags.
+	EscapeHTML bool
+
+	// EscapeLineSeparators, escapes U+2028 LINE SEPARATOR and U+2029
+	// PARAGRAPH SEPARATOR. Both are valid in JSON strings, but not in
+	// JavaScript, and must be escaped when the output is served as JSONP.
+	EscapeLineSeparators bool
+
+	// TimeFormat, is the format used to marshal a Time. It is either a time
+	// layout, as accepted by time.Time.Format, or one of the Unix TimeFormat
+	// constants, which encode a Time as a JSON number.
+	//
+	// If empty, times are encoded as RFC 3339 strings with sub-second
+	// precision added if present, matching time.Time.MarshalJSON. Use
+	// RFC3339Precision for a fixed number of fractional digits.
+	TimeFormat string
+
+	// OutOfRange, is the action taken when marshaling a time with a year
+	// outside of the range [0,9999] using a RFC 3339 layout. By default a
+	// *TimeError is returned.
+	OutOfRange InvalidTimeAction
+}
+
+// DefaultEncoder is the Encoder used by MarshalJSON, by default it matches the
+// escaping of encoding/json.
+//
+// DefaultEncoder is not safe to modify while values are being marshaled and
+// should only be changed during program initialization.
+var DefaultEncoder = Encoder{
+	EscapeHTML:           true,
+	EscapeLineSeparators: true,
+}
+
+// MarshalString, marshals String s into JSON using the escaping rules of
+// Encoder e.
+func (e *Encoder) MarshalString(s String) ([]byte, error) {
+	if s.Valid {
+		return e.AppendString(make([]byte, 0, len(s.String)+2), s)
+	}
+	return nullLiteral, nil
+}
+
+// AppendString, appends the JSON encoding of String s to dst using the
+// escaping rules of Encoder e.
+func (e *Encoder) AppendString(dst []byte, s String) ([]byte, error) {
+	if s.Valid {
+		return e.appendString(dst, s.String), nil
+	}
+	return append(dst, nullLiteral...), nil
+}
+
+// MarshalTime, marshals Time t into JSON using the time format of Encoder e.
+func (e *Encoder) MarshalTime(t Time) ([]byte, error) {
+	if t.Valid {
+		return e.AppendTime(make([]byte, 0, len(e.TimeFormat)+len(time.RFC3339Nano)+2), t)
+	}
+	return nullLiteral, nil
+}
+
+// AppendTime, appends the JSON encoding of Time t to dst using the time
+// format of Encoder e.
+func (e *Encoder) AppendTime(dst []byte, t Time) ([]byte, error) {
+	if t.Valid {
+		return e.appendTime(dst, t.Time)
+	}
+	return append(dst, nullLiteral...), nil
+}
@@ -0,0 +1,117 @@
+package null
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const (
+	lineSep = "\xe2\x80\xa8" // U+2028
+	paraSep = "\xe2\x80\xa9" // U+2029
+)
+
+var encoderTests = []struct {
+	enc Encoder
+	in  string
+	out string
+}{
+	{Encoder{}, "<&>", `"<&>"`},
+	{Encoder{}, lineSep + paraSep, `"` + lineSep + paraSep + `"`},
+	{Encoder{}, "\"\\\n", `"\"\\\n"`},
+	{Encoder{EscapeHTML: true}, "<&>", "\"\\u003c\\u0026\\u003e\""},
+	{Encoder{EscapeHTML: true}, lineSep + paraSep, `"` + lineSep + paraSep + `"`},
+	{Encoder{EscapeLineSeparators: true}, "<&>", `"<&>"`},
+	{Encoder{EscapeLineSeparators: true}, "a" + lineSep + "b" + paraSep + "c", "\"a\\u2028b\\u2029c\""},
+	{DefaultEncoder, "<a" + lineSep + ">", "\"\\u003ca\\u2028\\u003e\""},
+}
+
+func TestEncoderMarshalString(t *testing.T) {
+	for _, test := range encoderTests {
+		b, err := test.enc.MarshalString(NewString(test.in))
+		if err != nil {
+			t.Errorf("%+v: MarshalString(%q): %v", test.enc, test.in, err)
+			continue
+		}
+		if string(b) != test.out {
+			t.Errorf("%+v: MarshalString(%q) = %#q, want %#q", test.enc, test.in, b, test.out)
+		}
+	}
+	var e Encoder
+	b, err := e.MarshalString(String{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, nullLiteral) {
+		t.Errorf("MarshalString(String{}) = %#q, want %#q", b, nullLiteral)
+	}
+}
+
+func TestDefaultEncoder(t *testing.T) {
+	orig := DefaultEncoder
+	defer func() { DefaultEncoder = orig }()
+
+	const in = "<" + lineSep + ">"
+	want, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewString(in).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, want) {
+		t.Errorf("MarshalJSON(%q) = %#q, want %#q", in, b, want)
+	}
+
+	DefaultEncoder = Encoder{}
+	b, err = NewString(in).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"`+in+`"` {
+		t.Errorf("MarshalJSON(%q) = %#q, want %#q", in, b, `"`+in+`"`)
+	}
+}
+
+func TestUnescapedString(t *testing.T) {
+	orig := DefaultEncoder
+	defer func() { DefaultEncoder = orig }()
+
+	s := NewUnescapedString("<a href=\"x\">&</a>" + lineSep)
+	b, err := s.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "\"<a href=\\\"x\\\">&</a>\\u2028\""
+	if string(b) != want {
+		t.Errorf("MarshalJSON(%q) = %#q, want %#q", s.String, b, want)
+	}
+
+	// encoding/json escapes the output of MarshalJSON unless told not to.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(bytes.TrimSpace(buf.Bytes())); got != want {
+		t.Errorf("Encode(%q) = %#q, want %#q", s.String, got, want)
+	}
+
+	DefaultEncoder.EscapeLineSeparators = false
+	if b, _ = s.MarshalJSON(); string(b) != "\"<a href=\\\"x\\\">&</a>"+lineSep+"\"" {
+		t.Errorf("MarshalJSON(%q) = %#q: expected U+2028 to not be escaped", s.String, b)
+	}
+
+	var u UnescapedString
+	if err := json.Unmarshal(b, &u); err != nil {
+		t.Fatal(err)
+	}
+	if u != s {
+		t.Errorf("Unmarshal(%#q) = %+v, want %+v", b, u, s)
+	}
+	if b, _ = (UnescapedString{}).MarshalJSON(); !bytes.Equal(b, nullLiteral) {
+		t.Errorf("MarshalJSON(UnescapedString{}) = %#q, want %#q", b, nullLiteral)
+	}
+}
@@ -0,0 +1,141 @@
+package null
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Errors wrapped by a ScanError or UnmarshalError, which may be tested for
+// with errors.Is.
+var (
+	// ErrRange, indicates that a value is out of range for the target type.
+	// It is strconv.ErrRange, so errors returned by the strconv package
+	// match it as well.
+	ErrRange = strconv.ErrRange
+
+	// ErrSyntax, indicates that a value does not have the right syntax for
+	// the target type. It is strconv.ErrSyntax.
+	ErrSyntax = strconv.ErrSyntax
+
+	// ErrUnsupportedType, indicates that the type of a value cannot be
+	// converted to the target type, e.g. scanning a bool into an Int or
+	// unmarshaling a quoted number into an Int in DecodeStrict mode.
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// maxErrorValue, is the maximum length of the value recorded by a ScanError
+// or UnmarshalError.
+const maxErrorValue = 64
+
+// errorValue, returns the string s truncated to maxErrorValue bytes.
+func errorValue(s string) string {
+	if len(s) > maxErrorValue {
+		return s[:maxErrorValue-3] + "..."
+	}
+	return s
+}
+
+// A ScanError records a failed conversion of a database value by Scan.
+type ScanError struct {
+	Type   string // the target type, e.g. "null.Int"
+	Source string // the Go type of the database value, e.g. "[]uint8"
+	Value  string // the database value, truncated if long
+	Err    error  // the reason the conversion failed
+}
+
+// newScanError, returns a ScanError for storing value into type typ.
+func newScanError(typ string, value interface{}, err error) *ScanError {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		s = fmt.Sprint(v)
+	}
+	return &ScanError{
+		Type:   typ,
+		Source: fmt.Sprintf("%T", value),
+		Value:  errorValue(s),
+		Err:    err,
+	}
+}
+
+// Error, matches the error messages of database/sql.
+func (e *ScanError) Error() string {
+	if e.Err == ErrUnsupportedType {
+		return "unsupported Scan, storing driver.Value type " + e.Source +
+			" into type *" + e.Type
+	}
+	return "converting driver.Value type " + e.Source + " (" + strconv.Quote(e.Value) +
+		") to a " + scanKind(e.Type) + ": " + causeMessage(e.Err)
+}
+
+// Unwrap, returns the underlying error of ScanError e.
+func (e *ScanError) Unwrap() error { return e.Err }
+
+// scanKind, returns the name database/sql would use for the underlying type
+// of typ.
+func scanKind(typ string) string {
+	switch typ {
+	case "null.Int":
+		return "int"
+	case "null.Uint64":
+		return "uint64"
+	case "null.Float64":
+		return "float64"
+	case "null.Float32":
+		return "float32"
+	case "null.String", "null.UnescapedString":
+		return "string"
+	case "null.Bool":
+		return "bool"
+	case "null.Time":
+		return "time.Time"
+	}
+	return typ
+}
+
+// causeMessage, returns the message of err without the details repeated by
+// ScanError and UnmarshalError.
+func causeMessage(err error) string {
+	switch e := err.(type) {
+	case *strconv.NumError:
+		return e.Err.Error()
+	case *TimeError:
+		return e.Err.Error()
+	}
+	return err.Error()
+}
+
+// An UnmarshalError records a failed unmarshaling of a JSON value, or of
+// text by UnmarshalText.
+type UnmarshalError struct {
+	Type  string // the target type, e.g. "null.Int"
+	Value string // the JSON value or text, truncated if long
+	Err   error  // the reason the value could not be unmarshaled
+}
+
+// newUnmarshalError, returns an UnmarshalError for unmarshaling the JSON data
+// into type typ.
+func newUnmarshalError(typ string, data []byte, err error) *UnmarshalError {
+	return &UnmarshalError{Type: typ, Value: errorValue(string(data)), Err: err}
+}
+
+func (e *UnmarshalError) Error() string {
+	return "null: cannot unmarshal " + e.Value + " into type " + e.Type + ": " +
+		causeMessage(e.Err)
+}
+
+// Unwrap, returns the underlying error of UnmarshalError e.
+func (e *UnmarshalError) Unwrap() error { return e.Err }
+
+// Is, reports that an UnmarshalError caused by a *time.ParseError is an
+// ErrSyntax.
+func (e *UnmarshalError) Is(target error) bool {
+	_, ok := e.Err.(*time.ParseError)
+	return ok && target == ErrSyntax
+}
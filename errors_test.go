@@ -0,0 +1,152 @@
+package null
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var scanErrorTests = []struct {
+	dst sql.Scanner
+	in  interface{}
+	err error
+	msg string
+}{
+	{new(Int), []byte("300000000000000000000"), ErrRange,
+		`converting driver.Value type []uint8 ("300000000000000000000") to a int: value out of range`},
+	{new(Int), "abc", ErrSyntax,
+		`converting driver.Value type string ("abc") to a int: invalid syntax`},
+	{new(Int), true, ErrUnsupportedType,
+		`unsupported Scan, storing driver.Value type bool into type *null.Int`},
+	{new(Float64), "1.5x", ErrSyntax,
+		`converting driver.Value type string ("1.5x") to a float64: invalid syntax`},
+	{new(Float32), float64(1e39), ErrRange,
+		`converting driver.Value type float64 ("1e+39") to a float32: value out of range`},
+	{new(Float32), struct{}{}, ErrUnsupportedType,
+		`unsupported Scan, storing driver.Value type struct {} into type *null.Float32`},
+	{new(String), struct{}{}, ErrUnsupportedType,
+		`unsupported Scan, storing driver.Value type struct {} into type *null.String`},
+	{new(UnescapedString), struct{}{}, ErrUnsupportedType,
+		`unsupported Scan, storing driver.Value type struct {} into type *null.String`},
+	{new(Bool), "maybe", ErrSyntax,
+		`converting driver.Value type string ("maybe") to a bool: invalid syntax`},
+	{new(Bool), int64(2), ErrRange,
+		`converting driver.Value type int64 ("2") to a bool: value out of range`},
+	{new(Bool), 1.5, ErrUnsupportedType,
+		`unsupported Scan, storing driver.Value type float64 into type *null.Bool`},
+	{new(Time), "yesterday", ErrSyntax,
+		`converting driver.Value type string ("yesterday") to a time.Time: invalid syntax`},
+	{new(Time), int64(1), ErrUnsupportedType,
+		`unsupported Scan, storing driver.Value type int64 into type *null.Time`},
+}
+
+func TestScanError(t *testing.T) {
+	for _, test := range scanErrorTests {
+		err := test.dst.Scan(test.in)
+		var serr *ScanError
+		if !errors.As(err, &serr) {
+			t.Errorf("%T.Scan(%#v) = %v: want *ScanError", test.dst, test.in, err)
+			continue
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%T.Scan(%#v) = %v: want errors.Is %v", test.dst, test.in, err, test.err)
+		}
+		if err.Error() != test.msg {
+			t.Errorf("%T.Scan(%#v) error = %q want %q", test.dst, test.in, err.Error(), test.msg)
+		}
+	}
+}
+
+func TestScanErrorTimeError(t *testing.T) {
+	p := TimePolicy{InvalidDate: InvalidTimeError}
+	var v Time
+	err := p.ScanTime("2024-02-30", &v)
+	var terr *TimeError
+	if !errors.As(err, &terr) || !errors.Is(err, ErrInvalidDate) {
+		t.Fatalf("ScanTime = %v: want *TimeError wrapping ErrInvalidDate", err)
+	}
+	const want = `converting driver.Value type string ("2024-02-30") to a time.Time: null: invalid date`
+	if err.Error() != want {
+		t.Errorf("ScanTime error = %q want %q", err.Error(), want)
+	}
+}
+
+func TestScanFloat32Range(t *testing.T) {
+	for _, in := range []interface{}{float64(0), float64(-1), "-1.5", []byte("0")} {
+		var f Float32
+		if err := f.Scan(in); err != nil || !f.Valid {
+			t.Errorf("Float32.Scan(%#v) = %+v, %v: want valid Float32", in, f, err)
+		}
+	}
+}
+
+var unmarshalErrorTests = []struct {
+	dec Decoder
+	dst interface{}
+	in  string
+	err error
+	msg string
+}{
+	{Decoder{}, new(Int), `99999999999999999999`, ErrRange,
+		`null: cannot unmarshal 99999999999999999999 into type null.Int: value out of range`},
+	{Decoder{}, new(Int), `1x`, ErrSyntax,
+		`null: cannot unmarshal 1x into type null.Int: invalid syntax`},
+	{Decoder{}, new(Float64), `"a"`, ErrSyntax,
+		`null: cannot unmarshal "a" into type null.Float64: invalid syntax`},
+	{Decoder{}, new(Float32), `1e39`, ErrRange,
+		`null: cannot unmarshal 1e39 into type null.Float32: value out of range`},
+	{Decoder{}, new(String), `1`, ErrSyntax,
+		`null: cannot unmarshal 1 into type null.String: invalid syntax`},
+	{Decoder{}, new(Bool), `"maybe"`, ErrSyntax,
+		`null: cannot unmarshal "maybe" into type null.Bool: invalid syntax`},
+	{Decoder{}, new(Time), `"yesterday"`, ErrSyntax, ""},
+	{Decoder{}, new(Int), ``, ErrSyntax,
+		`null: cannot unmarshal  into type null.Int: invalid syntax`},
+	{Decoder{Mode: DecodeStrict}, new(Int), `"1"`, ErrUnsupportedType,
+		`null: cannot unmarshal "1" into type null.Int: unsupported type`},
+	{Decoder{TimeFormats: []string{TimeFormatUnix}}, new(Time), `1.`, ErrSyntax,
+		`null: cannot unmarshal 1. into type null.Time: invalid syntax`},
+	{Decoder{TimeFormats: []string{TimeFormatUnix}, Mode: DecodeStrict}, new(Time), `"1"`,
+		ErrUnsupportedType, `null: cannot unmarshal "1" into type null.Time: unsupported type`},
+	{Decoder{}, new(int), `1`, ErrUnsupportedType,
+		`null: cannot unmarshal 1 into type *int: unsupported type`},
+}
+
+func TestUnmarshalError(t *testing.T) {
+	for _, test := range unmarshalErrorTests {
+		err := test.dec.Unmarshal([]byte(test.in), test.dst)
+		var uerr *UnmarshalError
+		if !errors.As(err, &uerr) {
+			t.Errorf("%+v: Unmarshal(%q, %T) = %v: want *UnmarshalError", test.dec, test.in, test.dst, err)
+			continue
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%+v: Unmarshal(%q, %T) = %v: want errors.Is %v", test.dec, test.in, test.dst, err, test.err)
+		}
+		if test.msg != "" && err.Error() != test.msg {
+			t.Errorf("%+v: Unmarshal(%q, %T) error = %q want %q", test.dec, test.in, test.dst, err.Error(), test.msg)
+		}
+	}
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	var i Int
+	err := i.UnmarshalJSON([]byte(`true`))
+	if _, ok := err.(*UnmarshalError); !ok || !errors.Is(err, ErrSyntax) {
+		t.Errorf("UnmarshalJSON(true) = %v: want *UnmarshalError wrapping ErrSyntax", err)
+	}
+}
+
+func TestErrorValueTruncated(t *testing.T) {
+	long := strings.Repeat("1", 100)
+	var i Int
+	err := i.Scan(long)
+	var serr *ScanError
+	if !errors.As(err, &serr) {
+		t.Fatalf("Scan = %v: want *ScanError", err)
+	}
+	if len(serr.Value) != maxErrorValue || !strings.HasSuffix(serr.Value, "...") {
+		t.Errorf("ScanError.Value = %q: want truncated to %d bytes", serr.Value, maxErrorValue)
+	}
+}
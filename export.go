@@ -0,0 +1,100 @@
+package null
+
+import (
+	"database/sql"
+	"io"
+	"reflect"
+)
+
+// exportBufferSize, is the size at which the buffered output of ExportJSON
+// and ExportNDJSON is written.
+const exportBufferSize = 32 * 1024
+
+// rowAppender, is implemented by the types of this package.
+type rowAppender interface {
+	AppendJSON(dst []byte) ([]byte, error)
+}
+
+// ExportJSON, writes rows to w as a JSON array of objects, one per row, that
+// map column names to values, e.g. [{"id":1,"name":null}]. An empty result
+// is written as []. Each column is scanned into the type of this package
+// chosen by ColumnScanType and encoded with its AppendJSON method, using
+// DefaultEncoder. Rows is closed when ExportJSON returns.
+func ExportJSON(w io.Writer, rows *sql.Rows) error {
+	return exportRows(w, rows, false)
+}
+
+// ExportNDJSON, writes rows to w as newline delimited JSON, one object per
+// line, see ExportJSON. Nothing is written for an empty result.
+func ExportNDJSON(w io.Writer, rows *sql.Rows) error {
+	return exportRows(w, rows, true)
+}
+
+func exportRows(w io.Writer, rows *sql.Rows, ndjson bool) error {
+	defer rows.Close()
+
+	cts, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	dest := make([]interface{}, len(cts))
+	values := make([]rowAppender, len(cts))
+	keys := make([][]byte, len(cts))
+	for i, ct := range cts {
+		v := reflect.New(ColumnScanType(ct))
+		dest[i] = v.Interface()
+		values[i] = v.Interface().(rowAppender)
+
+		sep := byte(',')
+		if i == 0 {
+			sep = '{'
+		}
+		keys[i] = append(DefaultEncoder.appendString([]byte{sep}, ct.Name()), ':')
+	}
+
+	buf := make([]byte, 0, exportBufferSize+1024)
+	if !ndjson {
+		buf = append(buf, '[')
+	}
+	first := true
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if !ndjson && !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		if len(values) == 0 {
+			buf = append(buf, '{')
+		}
+		for i, v := range values {
+			buf = append(buf, keys[i]...)
+			if buf, err = v.AppendJSON(buf); err != nil {
+				return err
+			}
+		}
+		buf = append(buf, '}')
+		if ndjson {
+			buf = append(buf, '\n')
+		}
+		if len(buf) >= exportBufferSize {
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !ndjson {
+		buf = append(buf, ']')
+	}
+	if len(buf) > 0 {
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
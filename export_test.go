@@ -0,0 +1,106 @@
+package null
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var exportTable = fakeTable{
+	columns: []string{"id", "big", "name", "price", "ok", "at", "raw"},
+	types:   []string{"BIGINT", "BIGINT UNSIGNED", "VARCHAR", "DECIMAL", "BOOL", "DATETIME", ""},
+	scan: []reflect.Type{
+		nil, nil, nil,
+		reflect.TypeOf([]byte(nil)), nil, nil,
+		reflect.TypeOf(float64(0)),
+	},
+	rows: [][]driver.Value{
+		{int64(1), uint64(math.MaxUint64), []byte("<a>"), []byte("12.30"), int64(1), time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), float64(0.5)},
+		{nil, nil, nil, nil, nil, nil, nil},
+	},
+}
+
+const exportRow1 = `{"id":1,"big":18446744073709551615,"name":"\u003ca\u003e","price":"12.30",` +
+	`"ok":"true","at":"2006-01-02T15:04:05Z","raw":0.5}`
+
+const exportRow2 = `{"id":null,"big":null,"name":null,"price":null,"ok":null,"at":null,"raw":null}`
+
+func TestExportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportJSON(&buf, exportTable.open(t)); err != nil {
+		t.Fatal(err)
+	}
+	want := "[" + exportRow1 + "," + exportRow2 + "]"
+	if buf.String() != want {
+		t.Errorf("ExportJSON:\ngot:  %s\nwant: %s", buf.String(), want)
+	}
+	if !json.Valid(buf.Bytes()) {
+		t.Errorf("ExportJSON: invalid JSON: %s", buf.String())
+	}
+}
+
+func TestExportNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportNDJSON(&buf, exportTable.open(t)); err != nil {
+		t.Fatal(err)
+	}
+	want := exportRow1 + "\n" + exportRow2 + "\n"
+	if buf.String() != want {
+		t.Errorf("ExportNDJSON:\ngot:  %s\nwant: %s", buf.String(), want)
+	}
+}
+
+func TestExportEmpty(t *testing.T) {
+	empty := fakeTable{columns: []string{"id"}}
+	var buf bytes.Buffer
+	if err := ExportJSON(&buf, empty.open(t)); err != nil || buf.String() != "[]" {
+		t.Errorf("ExportJSON(empty) = %q, %v want []", buf.String(), err)
+	}
+	buf.Reset()
+	if err := ExportNDJSON(&buf, empty.open(t)); err != nil || buf.Len() != 0 {
+		t.Errorf("ExportNDJSON(empty) = %q, %v want no output", buf.String(), err)
+	}
+}
+
+func TestExportLarge(t *testing.T) {
+	table := fakeTable{columns: []string{"s"}, types: []string{"TEXT"}}
+	long := strings.Repeat("x", 1000)
+	for i := 0; i < 100; i++ {
+		table.rows = append(table.rows, []driver.Value{long})
+	}
+	var buf bytes.Buffer
+	if err := ExportJSON(&buf, table.open(t)); err != nil {
+		t.Fatal(err)
+	}
+	var out []map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 100 || out[99]["s"] != long {
+		t.Errorf("ExportJSON: got %d rows", len(out))
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("write error") }
+
+func TestExportErrors(t *testing.T) {
+	if err := ExportJSON(errWriter{}, exportTable.open(t)); err == nil {
+		t.Error("ExportJSON: expected write error")
+	}
+	bad := fakeTable{columns: []string{"f"}, types: []string{"DOUBLE"}, rows: [][]driver.Value{{math.NaN()}}}
+	if err := ExportJSON(new(bytes.Buffer), bad.open(t)); err == nil {
+		t.Error("ExportJSON(NaN): expected error")
+	}
+	scan := fakeTable{columns: []string{"n"}, types: []string{"INT"}, rows: [][]driver.Value{{"x"}}}
+	if err := ExportNDJSON(new(bytes.Buffer), scan.open(t)); !errors.Is(err, ErrSyntax) {
+		t.Errorf("ExportNDJSON = %v want %v", err, ErrSyntax)
+	}
+}
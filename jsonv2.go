@@ -0,0 +1,194 @@
+//go:build goexperiment.jsonv2
+
+package null
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+)
+
+// This file implements the encoding/json/v2 MarshalerTo and UnmarshalerFrom
+// interfaces. Values are written to and read from the jsontext stream
+// directly, so the v2 encoder does not need to go through MarshalJSON and
+// UnmarshalJSON and re-validate their output.
+//
+// Numeric types respect the json.StringifyNumbers option (and the `string`
+// struct tag option). String values are escaped according to the options of
+// the jsontext.Encoder (see jsontext.EscapeForHTML and jsontext.EscapeForJS)
+// instead of DefaultEncoder.
+
+// stringifyNumbers, reports if numbers should be encoded as JSON strings.
+func stringifyNumbers(opts jsontext.Options) bool {
+	v, _ := json.GetOption(opts, json.StringifyNumbers)
+	return v
+}
+
+// writeNumber, writes the JSON number appended by fn to Encoder enc, quoting
+// the number if json.StringifyNumbers is set.
+func writeNumber(enc *jsontext.Encoder, fn func([]byte) ([]byte, error)) error {
+	b := enc.AvailableBuffer()
+	quote := stringifyNumbers(enc.Options())
+	if quote {
+		b = append(b, '"')
+	}
+	b, err := fn(b)
+	if err != nil {
+		return err
+	}
+	if quote {
+		b = append(b, '"')
+	}
+	return enc.WriteValue(b)
+}
+
+// readValue, reads the next JSON value from Decoder dec and passes it to fn.
+func readValue(dec *jsontext.Decoder, fn func([]byte) error) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return fn(val)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface.
+func (i Int) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !i.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return writeNumber(enc, i.AppendJSON)
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface.
+func (i *Int) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, i.UnmarshalJSON)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface.
+func (u Uint64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !u.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return writeNumber(enc, u.AppendJSON)
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface.
+func (u *Uint64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, u.UnmarshalJSON)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface.
+func (f Float64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !f.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return writeNumber(enc, f.AppendJSON)
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface.
+func (f *Float64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, f.UnmarshalJSON)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface.
+func (f Float32) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !f.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return writeNumber(enc, f.AppendJSON)
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface.
+func (f *Float32) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, f.UnmarshalJSON)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface.
+func (s String) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !s.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteToken(jsontext.String(s.String))
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface.
+func (s *String) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, s.UnmarshalJSON)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface. Like String, the
+// HTML characters <, > and & are only escaped if the Encoder is configured to
+// do so.
+func (s UnescapedString) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return String(s).MarshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface.
+func (s *UnescapedString) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, s.UnmarshalJSON)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface.
+func (b Bool) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !b.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	if b.Bool {
+		return enc.WriteToken(jsontext.String("true"))
+	}
+	return enc.WriteToken(jsontext.String("false"))
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface.
+func (b *Bool) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, b.UnmarshalJSON)
+}
+
+// MarshalJSONTo, implements the json.MarshalerTo interface. The time is
+// formatted with DefaultEncoder, like MarshalJSON.
+//
+// The `format` struct tag option (e.g. format:unix or format:RFC3339) is not
+// supported: encoding/json/v2 rejects it on every struct field, and the
+// format is not available to MarshalerTo implementations through the
+// Encoder options. Configure DefaultEncoder.TimeFormat instead.
+func (t Time) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !t.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	b, err := t.AppendJSON(enc.AvailableBuffer())
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom, implements the json.UnmarshalerFrom interface. The
+// time is parsed with DefaultDecoder, like UnmarshalJSON. As with
+// MarshalJSONTo, the `format` struct tag option is not supported, configure
+// DefaultDecoder.TimeFormats or pass Decoder.UnmarshalOptions instead.
+func (t *Time) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return readValue(dec, t.UnmarshalJSON)
+}
+
+// UnmarshalOptions, returns json/v2 options that unmarshal the types of this
+// package with a copy of Decoder d instead of DefaultDecoder, for example:
+//
+//	dec := null.Decoder{Mode: null.DecodeStrict}
+//	err := json.Unmarshal(data, &v, dec.UnmarshalOptions())
+//
+// Unlike changing DefaultDecoder the Mode and TimeFormats only apply to the
+// call the options are passed to, so they are safe for concurrent use.
+func (d *Decoder) UnmarshalOptions() json.Options {
+	dd := *d
+	return json.WithUnmarshalers(json.JoinUnmarshalers(
+		json.UnmarshalFunc(dd.UnmarshalInt),
+		json.UnmarshalFunc(dd.UnmarshalUint64),
+		json.UnmarshalFunc(dd.UnmarshalFloat64),
+		json.UnmarshalFunc(dd.UnmarshalFloat32),
+		json.UnmarshalFunc(dd.UnmarshalString),
+		json.UnmarshalFunc(func(data []byte, s *UnescapedString) error {
+			return dd.UnmarshalString(data, (*String)(s))
+		}),
+		json.UnmarshalFunc(dd.UnmarshalBool),
+		json.UnmarshalFunc(dd.UnmarshalTime),
+	))
+}
@@ -0,0 +1,173 @@
+//go:build goexperiment.jsonv2
+
+package null
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"math"
+	"testing"
+	"time"
+)
+
+type jsonv2Struct struct {
+	Int       Int             `json:"int"`
+	Uint64    Uint64          `json:"uint64"`
+	Float64   Float64         `json:"float64"`
+	Float32   Float32         `json:"float32"`
+	String    String          `json:"string"`
+	Unescaped UnescapedString `json:"unescaped"`
+	Bool      Bool            `json:"bool"`
+	Time      Time            `json:"time"`
+}
+
+var jsonv2Value = jsonv2Struct{
+	Int:       NewInt(-12),
+	Uint64:    NewUint64(math.MaxUint64),
+	Float64:   NewFloat64(1.5),
+	Float32:   NewFloat32(123.456),
+	String:    NewString("<a>"),
+	Unescaped: NewUnescapedString("<b>"),
+	Bool:      NewBool(true),
+	Time:      NewTime(time.Date(2017, 10, 20, 23, 4, 56, 123456000, time.UTC)),
+}
+
+func TestMarshalJSONTo(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		opts []json.Options
+		want string
+	}{
+		{
+			v:    jsonv2Value,
+			want: `{"int":-12,"uint64":18446744073709551615,"float64":1.5,"float32":123.456,"string":"<a>","unescaped":"<b>","bool":"true","time":"2017-10-20T23:04:56.123456Z"}`,
+		},
+		{
+			v:    jsonv2Value,
+			opts: []json.Options{json.StringifyNumbers(true), jsontext.EscapeForHTML(true)},
+			want: `{"int":"-12","uint64":"18446744073709551615","float64":"1.5","float32":"123.456","string":"\u003ca\u003e","unescaped":"\u003cb\u003e","bool":"true","time":"2017-10-20T23:04:56.123456Z"}`,
+		},
+		{
+			v:    jsonv2Struct{},
+			want: `{"int":null,"uint64":null,"float64":null,"float32":null,"string":null,"unescaped":null,"bool":null,"time":null}`,
+		},
+		{
+			v: struct {
+				A Int     `json:",string"`
+				B Float64 `json:",string"`
+				C Int
+			}{NewInt(1), NewFloat64(2), NewInt(3)},
+			want: `{"A":"1","B":"2","C":3}`,
+		},
+	}
+	for _, test := range tests {
+		b, err := json.Marshal(test.v, test.opts...)
+		if err != nil {
+			t.Errorf("Marshal(%+v): %v", test.v, err)
+			continue
+		}
+		if string(b) != test.want {
+			t.Errorf("Marshal(%+v):\ngot:  %s\nwant: %s", test.v, b, test.want)
+		}
+	}
+}
+
+func TestUnmarshalJSONFrom(t *testing.T) {
+	inputs := []string{
+		`{"int":-12,"uint64":18446744073709551615,"float64":1.5,"float32":123.456,"string":"<a>","unescaped":"<b>","bool":"true","time":"2017-10-20T23:04:56.123456Z"}`,
+		`{"int":"-12","uint64":"18446744073709551615","float64":"1.5","float32":"123.456","string":"<a>","unescaped":"<b>","bool":true,"time":"2017-10-20T23:04:56.123456Z"}`,
+	}
+	for _, in := range inputs {
+		var v jsonv2Struct
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Errorf("Unmarshal(%s): %v", in, err)
+			continue
+		}
+		if !v.Time.Time.Equal(jsonv2Value.Time.Time) {
+			t.Errorf("Unmarshal(%s): Time = %v, want %v", in, v.Time.Time, jsonv2Value.Time.Time)
+		}
+		v.Time = jsonv2Value.Time
+		if v != jsonv2Value {
+			t.Errorf("Unmarshal(%s):\ngot:  %+v\nwant: %+v", in, v, jsonv2Value)
+		}
+	}
+
+	v := jsonv2Value
+	in := `{"int":null,"uint64":null,"float64":null,"float32":null,"string":null,"unescaped":null,"bool":null,"time":null}`
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v != (jsonv2Struct{}) {
+		t.Errorf("Unmarshal(%s) = %+v, want all invalid", in, v)
+	}
+
+	if err := json.Unmarshal([]byte(`{"int":"abc"}`), &v); err == nil {
+		t.Error("Unmarshal: expected error for invalid Int")
+	}
+	if err := json.Unmarshal([]byte(`{"uint64":-1}`), &v); err == nil {
+		t.Error("Unmarshal: expected error for negative Uint64")
+	}
+}
+
+func TestJSONv2TimeFormat(t *testing.T) {
+	// The `format` tag option is rejected by encoding/json/v2, for Time as
+	// for time.Time.
+	type formatted struct {
+		T Time `json:"t,format:unix"`
+	}
+	if b, err := json.Marshal(formatted{NewTime(time.Unix(1, 0))}); err == nil {
+		t.Errorf("Marshal(format:unix) = %s, want error", b)
+	}
+	var f formatted
+	if err := json.Unmarshal([]byte(`{"t":1}`), &f); err == nil {
+		t.Errorf("Unmarshal(format:unix) = %+v, want error", f)
+	}
+
+	// The format is configured with DefaultEncoder and DefaultDecoder.
+	defer func(e Encoder, d Decoder) { DefaultEncoder, DefaultDecoder = e, d }(DefaultEncoder, DefaultDecoder)
+	DefaultEncoder.TimeFormat = TimeFormatUnix
+	DefaultDecoder.TimeFormats = []string{TimeFormatUnix}
+	type unix struct {
+		T Time `json:"t"`
+	}
+	b, err := json.Marshal(unix{NewTime(time.Unix(1, 0))})
+	if err != nil || string(b) != `{"t":1}` {
+		t.Errorf("Marshal = %s, %v want %s", b, err, `{"t":1}`)
+	}
+	var u unix
+	if err := json.Unmarshal([]byte(`{"t":2}`), &u); err != nil || !u.T.Time.Equal(time.Unix(2, 0)) {
+		t.Errorf("Unmarshal = %+v, %v want %v", u, err, time.Unix(2, 0))
+	}
+}
+
+func TestDecoderUnmarshalOptions(t *testing.T) {
+	var v struct {
+		I Int
+		U Uint64
+		S UnescapedString
+		B Bool
+		T Time
+	}
+	const in = `{"I":"1","U":" 2 ","S":"","B":"1","T":"2006-01-02"}`
+	if err := json.Unmarshal([]byte(in), &v); err == nil {
+		t.Errorf("Unmarshal(%s): expected error with DefaultDecoder", in)
+	}
+	lenient := Decoder{Mode: DecodeLenient, TimeFormats: []string{"2006-01-02"}}
+	if err := json.Unmarshal([]byte(in), &v, lenient.UnmarshalOptions()); err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)
+	if v.I != NewInt(1) || v.U != NewUint64(2) || v.S.Valid || v.B != NewBool(true) || v.T != NewTime(at) {
+		t.Errorf("Unmarshal(%s) = %+v", in, v)
+	}
+	strict := Decoder{Mode: DecodeStrict}
+	if err := json.Unmarshal([]byte(`{"I":"1"}`), &v, strict.UnmarshalOptions()); err == nil {
+		t.Errorf("Unmarshal(%s): expected error in strict mode", `{"I":"1"}`)
+	}
+	if err := json.Unmarshal([]byte(`{"I":null,"B":"true"}`), &v, strict.UnmarshalOptions()); err != nil || v.I.Valid || v.B != NewBool(true) {
+		t.Errorf("Unmarshal = %+v, %v", v, err)
+	}
+	if DefaultDecoder.Mode != DecodeDefault {
+		t.Errorf("DefaultDecoder.Mode = %d, want unchanged", DefaultDecoder.Mode)
+	}
+}
@@ -0,0 +1,322 @@
+package null
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadDataTimeLayout, is the layout of times written by a LoadDataWriter,
+// the format of a MySQL DATETIME(6) column.
+const LoadDataTimeLayout = "2006-01-02 15:04:05.000000"
+
+// loadDataNull, is the representation of NULL in the LOAD DATA format.
+const loadDataNull = `\N`
+
+// A LoadDataWriter writes rows in the format read by the MySQL LOAD DATA
+// [LOCAL] INFILE statement with the default FIELDS ESCAPED BY '\\' and no
+// FIELDS ENCLOSED BY. NULL is written as \N and backslashes, NUL, tabs,
+// newlines, carriage returns, Ctrl-Z and the first character of each
+// terminator are escaped with a backslash. Statement returns a LOAD DATA
+// statement that matches the terminators.
+//
+// Times are written in LoadDataTimeLayout, the format of DATETIME(6), after
+// being normalized by DefaultTimePolicy. Set its Location to the time zone
+// of the MySQL session to store the same instant.
+//
+// Writes are buffered, Flush must be called to write any buffered data.
+type LoadDataWriter struct {
+	FieldsTerminatedBy string // set to "\t" by NewLoadDataWriter
+	LinesTerminatedBy  string // set to "\n" by NewLoadDataWriter
+
+	w   *bufio.Writer
+	buf []byte
+}
+
+// NewLoadDataWriter, returns a LoadDataWriter that writes to w with the
+// default terminators of LOAD DATA, tab and newline.
+func NewLoadDataWriter(w io.Writer) *LoadDataWriter {
+	return &LoadDataWriter{
+		FieldsTerminatedBy: "\t",
+		LinesTerminatedBy:  "\n",
+		w:                  bufio.NewWriter(w),
+	}
+}
+
+// Write, writes values as a row. Values may be of the types of this package,
+// pointers, which are NULL when nil, time.Time, driver.Valuer or basic types.
+func (w *LoadDataWriter) Write(values ...interface{}) error {
+	buf := w.buf[:0]
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, w.FieldsTerminatedBy...)
+		}
+		var err error
+		if buf, err = w.appendValue(buf, reflect.ValueOf(v)); err != nil {
+			return fmt.Errorf("null: LOAD DATA: field %d: %w", i, err)
+		}
+	}
+	return w.writeLine(buf)
+}
+
+// WriteStruct, writes the fields of the struct v, or the struct v points to,
+// as a row, in the order returned by LoadDataColumns. Fields may be of the
+// types accepted by Write.
+func (w *LoadDataWriter) WriteStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errLoadDataStruct
+	}
+	p := planFor(rv.Type(), "db")
+	buf := w.buf[:0]
+	for i, name := range p.columns {
+		if i > 0 {
+			buf = append(buf, w.FieldsTerminatedBy...)
+		}
+		fi, _ := p.field(name)
+		var err error
+		if buf, err = w.appendValue(buf, rv.FieldByIndex(fi)); err != nil {
+			return fmt.Errorf("null: LOAD DATA: column %s: %w", name, err)
+		}
+	}
+	return w.writeLine(buf)
+}
+
+func (w *LoadDataWriter) writeLine(buf []byte) error {
+	buf = append(buf, w.LinesTerminatedBy...)
+	w.buf = buf
+	_, err := w.w.Write(buf)
+	return err
+}
+
+// Flush, writes any buffered data to the underlying io.Writer.
+func (w *LoadDataWriter) Flush() error {
+	return w.w.Flush()
+}
+
+var errLoadDataStruct = errors.New("null: LOAD DATA: value must be a struct or a non-nil pointer to a struct")
+
+// LoadDataColumns, returns the column names of the struct v, or the struct v
+// points to, in the order its fields are written by WriteStruct. Columns are
+// matched to fields like ScanStruct matches them.
+func LoadDataColumns(v interface{}) ([]string, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errLoadDataStruct
+	}
+	return append([]string(nil), planFor(t, "db").columns...), nil
+}
+
+// Statement, returns a LOAD DATA LOCAL INFILE statement that loads file into
+// table with the terminators of w. The columns are listed if not empty.
+func (w *LoadDataWriter) Statement(file, table string, columns []string) string {
+	var b strings.Builder
+	b.WriteString("LOAD DATA LOCAL INFILE ")
+	b.WriteString(quoteLoadDataString(file))
+	b.WriteString(" INTO TABLE ")
+	b.WriteString(quoteLoadDataIdent(table))
+	b.WriteString(" FIELDS TERMINATED BY ")
+	b.WriteString(quoteLoadDataString(w.FieldsTerminatedBy))
+	b.WriteString(` ESCAPED BY '\\' LINES TERMINATED BY `)
+	b.WriteString(quoteLoadDataString(w.LinesTerminatedBy))
+	for i, name := range columns {
+		if i == 0 {
+			b.WriteString(" (")
+		} else {
+			b.WriteString(", ")
+		}
+		b.WriteString(quoteLoadDataIdent(name))
+		if i == len(columns)-1 {
+			b.WriteByte(')')
+		}
+	}
+	return b.String()
+}
+
+// quoteLoadDataString, returns s as a MySQL string literal.
+func quoteLoadDataString(s string) string {
+	return "'" + loadDataStringReplacer.Replace(s) + "'"
+}
+
+var loadDataStringReplacer = strings.NewReplacer(
+	`\`, `\\`, `'`, `\'`, "\x00", `\0`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "\x1a", `\Z`,
+)
+
+// quoteLoadDataIdent, returns the identifier name quoted with backticks. A
+// qualified name, db.table, is quoted as `db`.`table`.
+func quoteLoadDataIdent(name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = "`" + strings.ReplaceAll(p, "`", "``") + "`"
+	}
+	return strings.Join(parts, ".")
+}
+
+// appendEscaped, appends s to dst escaping the characters that have a special
+// meaning in a LOAD DATA field.
+func (w *LoadDataWriter) appendEscaped(dst []byte, s string) []byte {
+	fieldTerm, lineTerm := -1, -1
+	if w.FieldsTerminatedBy != "" {
+		fieldTerm = int(w.FieldsTerminatedBy[0])
+	}
+	if w.LinesTerminatedBy != "" {
+		lineTerm = int(w.LinesTerminatedBy[0])
+	}
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		var esc byte
+		switch c {
+		case '\\':
+			esc = '\\'
+		case 0:
+			esc = '0'
+		case '\t':
+			esc = 't'
+		case '\n':
+			esc = 'n'
+		case '\r':
+			esc = 'r'
+		case 0x1a:
+			esc = 'Z'
+		default:
+			if int(c) != fieldTerm && int(c) != lineTerm {
+				continue
+			}
+			esc = c
+		}
+		dst = append(dst, s[start:i]...)
+		dst = append(dst, '\\', esc)
+		start = i + 1
+	}
+	return append(dst, s[start:]...)
+}
+
+// appendValue, appends the LOAD DATA field of v to dst.
+func (w *LoadDataWriter) appendValue(dst []byte, v reflect.Value) ([]byte, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return append(dst, loadDataNull...), nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return append(dst, loadDataNull...), nil
+	}
+	var value interface{}
+	switch x := v.Interface().(type) {
+	case Int:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return strconv.AppendInt(dst, int64(x.Int), 10), nil
+	case Uint64:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return strconv.AppendUint(dst, x.Uint64, 10), nil
+	case Float64:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return appendLoadDataFloat(dst, x.Float64, 64)
+	case Float32:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return appendLoadDataFloat(dst, float64(x.Float32), 32)
+	case String:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return w.appendEscaped(dst, x.String), nil
+	case UnescapedString:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return w.appendEscaped(dst, x.String), nil
+	case Bool:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return appendLoadDataBool(dst, x.Bool), nil
+	case Time:
+		if !x.Valid {
+			return append(dst, loadDataNull...), nil
+		}
+		return appendLoadDataTime(dst, x.Time)
+	case driver.Valuer:
+		dv, err := x.Value()
+		if err != nil {
+			return dst, err
+		}
+		if dv == nil {
+			return append(dst, loadDataNull...), nil
+		}
+		value = dv
+	default:
+		value = x
+	}
+
+	switch x := value.(type) {
+	case time.Time:
+		return appendLoadDataTime(dst, x)
+	case []byte:
+		return w.appendEscaped(dst, string(x)), nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return appendLoadDataBool(dst, rv.Bool()), nil
+	case reflect.Float32:
+		return appendLoadDataFloat(dst, rv.Float(), 32)
+	case reflect.Float64:
+		return appendLoadDataFloat(dst, rv.Float(), 64)
+	}
+	s, err := DefaultTimePolicy.convertString(value)
+	if err != nil {
+		return dst, err
+	}
+	return w.appendEscaped(dst, s), nil
+}
+
+// appendLoadDataFloat, appends float f to dst. MySQL does not support NaN
+// or infinities, which return an error wrapping ErrRange.
+func appendLoadDataFloat(dst []byte, f float64, bitSize int) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, fmt.Errorf("cannot write %v: %w", f, ErrRange)
+	}
+	return strconv.AppendFloat(dst, f, 'g', -1, bitSize), nil
+}
+
+// appendLoadDataBool, appends b to dst as 1 or 0.
+func appendLoadDataBool(dst []byte, b bool) []byte {
+	if b {
+		return append(dst, '1')
+	}
+	return append(dst, '0')
+}
+
+// appendLoadDataTime, appends t, normalized by DefaultTimePolicy, to dst in
+// LoadDataTimeLayout. Years outside 0 to 9999 cannot be written in the
+// layout, which return an error wrapping ErrRange.
+func appendLoadDataTime(dst []byte, t time.Time) ([]byte, error) {
+	t = DefaultTimePolicy.Normalize(t)
+	if y := t.Year(); y < 0 || y > 9999 {
+		return dst, fmt.Errorf("cannot write %v: %w", t, ErrRange)
+	}
+	return t.AppendFormat(dst, LoadDataTimeLayout), nil
+}
@@ -0,0 +1,141 @@
+package null
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type loadDataRow struct {
+	ID      Int    `db:"id"`
+	Big     Uint64 `db:"big"`
+	Name    String `db:"name"`
+	Score   Float64
+	Ratio   Float32
+	Active  Bool      `db:"active"`
+	Created Time      `db:"created_at"`
+	Note    *string   `db:"note"`
+	Count   int       `db:"count"`
+	Seen    time.Time `db:"seen"`
+	Skipped String    `db:"-"`
+}
+
+func TestLoadDataWriter(t *testing.T) {
+	note := "n"
+	at := time.Date(2006, 1, 2, 15, 4, 5, 123456789, time.UTC)
+	rows := []loadDataRow{
+		{
+			ID: NewInt(-1), Big: NewUint64(math.MaxUint64),
+			Name:  NewString("a\tb\nc\\d\x00e\r\x1a"),
+			Score: NewFloat64(0.1), Ratio: NewFloat32(0.1), Active: NewBool(true),
+			Created: NewTime(at), Note: &note, Count: 3, Seen: at,
+		},
+		{Name: NewString(`\N`), Active: NewBool(false)},
+	}
+	var buf bytes.Buffer
+	w := NewLoadDataWriter(&buf)
+	for i := range rows {
+		if err := w.WriteStruct(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "-1\t18446744073709551615\ta\\tb\\nc\\\\d\\0e\\r\\Z\t0.1\t0.1\t1\t" +
+		"2006-01-02 15:04:05.123456\tn\t3\t2006-01-02 15:04:05.123456\n" +
+		"\\N\t\\N\t\\\\N\t\\N\t\\N\t0\t\\N\t\\N\t0\t0001-01-01 00:00:00.000000\n"
+	if buf.String() != want {
+		t.Errorf("WriteStruct:\ngot:  %q\nwant: %q", buf.String(), want)
+	}
+
+	columns, err := LoadDataColumns(loadDataRow{})
+	wantColumns := []string{"id", "big", "name", "Score", "Ratio", "active", "created_at", "note", "count", "seen"}
+	if err != nil || !reflect.DeepEqual(columns, wantColumns) {
+		t.Errorf("LoadDataColumns = %q, %v want %q", columns, err, wantColumns)
+	}
+}
+
+func TestLoadDataWriterTerminators(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewLoadDataWriter(&buf)
+	w.FieldsTerminatedBy = ","
+	w.LinesTerminatedBy = "|\r\n"
+	if err := w.Write(NewString("a,b|c"), nil, 1.5, []byte("x\ty"), true, "s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(Int{}, (*int)(nil), NewInt(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := "a\\,b\\|c,\\N,1.5,x\\ty,1,s|\r\n\\N,\\N,2|\r\n"
+	if buf.String() != want {
+		t.Errorf("Write:\ngot:  %q\nwant: %q", buf.String(), want)
+	}
+}
+
+func TestLoadDataTimePolicy(t *testing.T) {
+	defer func(p TimePolicy) { DefaultTimePolicy = p }(DefaultTimePolicy)
+	DefaultTimePolicy.Location = time.UTC
+
+	var buf bytes.Buffer
+	w := NewLoadDataWriter(&buf)
+	at := time.Date(2006, 1, 2, 15, 4, 5, 0, time.FixedZone("", -7*3600))
+	if err := w.Write(NewTime(at)); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+	if want := "2006-01-02 22:04:05.000000\n"; buf.String() != want {
+		t.Errorf("Write(%v) = %q want %q", at, buf.String(), want)
+	}
+}
+
+func TestLoadDataStatement(t *testing.T) {
+	w := NewLoadDataWriter(io.Discard)
+	got := w.Statement("/tmp/it's.tsv", "db.ta`ble", []string{"id", "name"})
+	want := "LOAD DATA LOCAL INFILE '/tmp/it\\'s.tsv' INTO TABLE `db`.`ta``ble` " +
+		"FIELDS TERMINATED BY '\\t' ESCAPED BY '\\\\' LINES TERMINATED BY '\\n' (`id`, `name`)"
+	if got != want {
+		t.Errorf("Statement:\ngot:  %s\nwant: %s", got, want)
+	}
+	w.FieldsTerminatedBy = ","
+	w.LinesTerminatedBy = "\r\n"
+	got = w.Statement("f", "t", nil)
+	want = "LOAD DATA LOCAL INFILE 'f' INTO TABLE `t` FIELDS TERMINATED BY ',' ESCAPED BY '\\\\' LINES TERMINATED BY '\\r\\n'"
+	if got != want {
+		t.Errorf("Statement:\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestLoadDataErrors(t *testing.T) {
+	w := NewLoadDataWriter(io.Discard)
+	if err := w.Write(NewFloat64(math.NaN())); !errors.Is(err, ErrRange) {
+		t.Errorf("Write(NaN) = %v want %v", err, ErrRange)
+	}
+	if err := w.Write(math.Inf(1)); !errors.Is(err, ErrRange) {
+		t.Errorf("Write(+Inf) = %v want %v", err, ErrRange)
+	}
+	for _, v := range []interface{}{
+		time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		NewTime(time.Date(-1, 12, 31, 0, 0, 0, 0, time.UTC)),
+	} {
+		if err := w.Write(v); !errors.Is(err, ErrRange) {
+			t.Errorf("Write(%v) = %v want %v", v, err, ErrRange)
+		}
+	}
+	if err := w.Write(make(chan int)); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Write(chan) = %v want %v", err, ErrUnsupportedType)
+	}
+	if err := w.WriteStruct(1); err != errLoadDataStruct {
+		t.Errorf("WriteStruct(1) = %v want %v", err, errLoadDataStruct)
+	}
+	if _, err := LoadDataColumns(nil); err != errLoadDataStruct {
+		t.Errorf("LoadDataColumns(nil) = %v want %v", err, errLoadDataStruct)
+	}
+}
@@ -0,0 +1,196 @@
+package null
+
+import (
+	"time"
+
+	"github.com/charlievieth/null/msgpack"
+)
+
+// AppendMsgpack, appends the MessagePack encoding of Int i to dst. NULL is
+// encoded as nil.
+func (i Int) AppendMsgpack(dst []byte) ([]byte, error) {
+	if i.Valid {
+		return msgpack.AppendInt(dst, int64(i.Int)), nil
+	}
+	return msgpack.AppendNil(dst), nil
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of b into Int i and
+// returns the remaining bytes.
+func (i *Int) DecodeMsgpack(b []byte) ([]byte, error) {
+	if msgpack.IsNil(b) {
+		i.Int, i.Valid = 0, false
+		return b[1:], nil
+	}
+	n, rest, err := msgpack.ReadInt(b)
+	if err == nil && int64(int(n)) != n {
+		err = msgpack.ErrRange
+	}
+	if err != nil {
+		i.Int, i.Valid = 0, false
+		return b, err
+	}
+	i.Int, i.Valid = int(n), true
+	return rest, nil
+}
+
+// AppendMsgpack, appends the MessagePack encoding of Uint64 u to dst. NULL
+// is encoded as nil.
+func (u Uint64) AppendMsgpack(dst []byte) ([]byte, error) {
+	if u.Valid {
+		return msgpack.AppendUint(dst, u.Uint64), nil
+	}
+	return msgpack.AppendNil(dst), nil
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of b into Uint64 u and
+// returns the remaining bytes.
+func (u *Uint64) DecodeMsgpack(b []byte) ([]byte, error) {
+	if msgpack.IsNil(b) {
+		u.Uint64, u.Valid = 0, false
+		return b[1:], nil
+	}
+	n, rest, err := msgpack.ReadUint(b)
+	if err != nil {
+		u.Uint64, u.Valid = 0, false
+		return b, err
+	}
+	u.Uint64, u.Valid = n, true
+	return rest, nil
+}
+
+// AppendMsgpack, appends the MessagePack encoding of Float64 f to dst. NULL
+// is encoded as nil.
+func (f Float64) AppendMsgpack(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return msgpack.AppendFloat64(dst, f.Float64), nil
+	}
+	return msgpack.AppendNil(dst), nil
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of b into Float64 f and
+// returns the remaining bytes.
+func (f *Float64) DecodeMsgpack(b []byte) ([]byte, error) {
+	if msgpack.IsNil(b) {
+		f.Float64, f.Valid = 0, false
+		return b[1:], nil
+	}
+	v, rest, err := msgpack.ReadFloat64(b)
+	if err != nil {
+		f.Float64, f.Valid = 0, false
+		return b, err
+	}
+	f.Float64, f.Valid = v, true
+	return rest, nil
+}
+
+// AppendMsgpack, appends the MessagePack encoding of Float32 f to dst. NULL
+// is encoded as nil.
+func (f Float32) AppendMsgpack(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return msgpack.AppendFloat32(dst, f.Float32), nil
+	}
+	return msgpack.AppendNil(dst), nil
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of b into Float32 f and
+// returns the remaining bytes.
+func (f *Float32) DecodeMsgpack(b []byte) ([]byte, error) {
+	if msgpack.IsNil(b) {
+		f.Float32, f.Valid = 0, false
+		return b[1:], nil
+	}
+	v, rest, err := msgpack.ReadFloat32(b)
+	if err != nil {
+		f.Float32, f.Valid = 0, false
+		return b, err
+	}
+	f.Float32, f.Valid = v, true
+	return rest, nil
+}
+
+// AppendMsgpack, appends the MessagePack encoding of String s to dst. NULL
+// is encoded as nil.
+func (s String) AppendMsgpack(dst []byte) ([]byte, error) {
+	if s.Valid {
+		return msgpack.AppendString(dst, s.String), nil
+	}
+	return msgpack.AppendNil(dst), nil
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of b, a str or bin, into
+// String s and returns the remaining bytes.
+func (s *String) DecodeMsgpack(b []byte) ([]byte, error) {
+	if msgpack.IsNil(b) {
+		s.String, s.Valid = "", false
+		return b[1:], nil
+	}
+	v, rest, err := msgpack.ReadString(b)
+	if err != nil {
+		s.String, s.Valid = "", false
+		return b, err
+	}
+	s.String, s.Valid = v, true
+	return rest, nil
+}
+
+// AppendMsgpack, appends the MessagePack encoding of UnescapedString s to dst.
+func (s UnescapedString) AppendMsgpack(dst []byte) ([]byte, error) {
+	return String(s).AppendMsgpack(dst)
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of b into
+// UnescapedString s and returns the remaining bytes.
+func (s *UnescapedString) DecodeMsgpack(b []byte) ([]byte, error) {
+	return (*String)(s).DecodeMsgpack(b)
+}
+
+// AppendMsgpack, appends the MessagePack encoding of Bool b to dst. NULL is
+// encoded as nil.
+func (b Bool) AppendMsgpack(dst []byte) ([]byte, error) {
+	if b.Valid {
+		return msgpack.AppendBool(dst, b.Bool), nil
+	}
+	return msgpack.AppendNil(dst), nil
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of data into Bool b and
+// returns the remaining bytes.
+func (b *Bool) DecodeMsgpack(data []byte) ([]byte, error) {
+	if msgpack.IsNil(data) {
+		b.Bool, b.Valid = false, false
+		return data[1:], nil
+	}
+	v, rest, err := msgpack.ReadBool(data)
+	if err != nil {
+		b.Bool, b.Valid = false, false
+		return data, err
+	}
+	b.Bool, b.Valid = v, true
+	return rest, nil
+}
+
+// AppendMsgpack, appends the MessagePack encoding of Time t, using the
+// timestamp extension type, to dst. NULL is encoded as nil.
+func (t Time) AppendMsgpack(dst []byte) ([]byte, error) {
+	if t.Valid {
+		return msgpack.AppendTime(dst, t.Time), nil
+	}
+	return msgpack.AppendNil(dst), nil
+}
+
+// DecodeMsgpack, decodes the next MessagePack value of b, a timestamp, into
+// Time t and returns the remaining bytes. The time is in UTC.
+func (t *Time) DecodeMsgpack(b []byte) ([]byte, error) {
+	if msgpack.IsNil(b) {
+		t.Time, t.Valid = time.Time{}, false
+		return b[1:], nil
+	}
+	v, rest, err := msgpack.ReadTime(b)
+	if err != nil {
+		t.Time, t.Valid = time.Time{}, false
+		return b, err
+	}
+	t.Time, t.Valid = v, true
+	return rest, nil
+}
@@ -0,0 +1,440 @@
+// Package msgpack implements a minimal, dependency-free MessagePack codec for
+// the value types of package null.
+//
+// Values are appended to and read from byte slices. Every Read function
+// returns the decoded value and the remaining bytes of b following it.
+//
+// See https://github.com/msgpack/msgpack/blob/master/spec.md for the format.
+package msgpack
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// MessagePack format bytes.
+const (
+	Nil      = 0xc0
+	False    = 0xc2
+	True     = 0xc3
+	Bin8     = 0xc4
+	Bin16    = 0xc5
+	Bin32    = 0xc6
+	Ext8     = 0xc7
+	Ext16    = 0xc8
+	Ext32    = 0xc9
+	Float32  = 0xca
+	Float64  = 0xcb
+	Uint8    = 0xcc
+	Uint16   = 0xcd
+	Uint32   = 0xce
+	Uint64   = 0xcf
+	Int8     = 0xd0
+	Int16    = 0xd1
+	Int32    = 0xd2
+	Int64    = 0xd3
+	FixExt1  = 0xd4
+	FixExt2  = 0xd5
+	FixExt4  = 0xd6
+	FixExt8  = 0xd7
+	FixExt16 = 0xd8
+	Str8     = 0xd9
+	Str16    = 0xda
+	Str32    = 0xdb
+)
+
+// TimestampType, is the extension type of the MessagePack timestamp.
+const TimestampType = -1
+
+// ErrShortBytes, is returned when b is too short to hold the value read.
+var ErrShortBytes = errors.New("msgpack: too few bytes")
+
+// ErrRange, is returned when a value is out of range for the type read.
+var ErrRange = errors.New("msgpack: value out of range")
+
+// A TypeError is returned when the next value is not of the type read.
+type TypeError struct {
+	Type   string // the type read
+	Format byte   // the format byte of the value
+}
+
+func (e *TypeError) Error() string {
+	return "msgpack: cannot read format 0x" + strconv.FormatUint(uint64(e.Format), 16) +
+		" as " + e.Type
+}
+
+// AppendNil, appends a nil value to dst.
+func AppendNil(dst []byte) []byte {
+	return append(dst, Nil)
+}
+
+// AppendBool, appends the bool v to dst.
+func AppendBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, True)
+	}
+	return append(dst, False)
+}
+
+// AppendInt, appends the int v to dst using the smallest encoding.
+func AppendInt(dst []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return AppendUint(dst, uint64(v))
+	case v >= -32:
+		return append(dst, byte(v))
+	case v >= math.MinInt8:
+		return append(dst, Int8, byte(v))
+	case v >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(dst, Int16), uint16(v))
+	case v >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(dst, Int32), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(dst, Int64), uint64(v))
+}
+
+// AppendUint, appends the uint v to dst using the smallest encoding.
+func AppendUint(dst []byte, v uint64) []byte {
+	switch {
+	case v <= math.MaxInt8:
+		return append(dst, byte(v))
+	case v <= math.MaxUint8:
+		return append(dst, Uint8, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, Uint16), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, Uint32), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(dst, Uint64), v)
+}
+
+// AppendFloat64, appends the float64 v to dst.
+func AppendFloat64(dst []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(append(dst, Float64), math.Float64bits(v))
+}
+
+// AppendFloat32, appends the float32 v to dst.
+func AppendFloat32(dst []byte, v float32) []byte {
+	return binary.BigEndian.AppendUint32(append(dst, Float32), math.Float32bits(v))
+}
+
+// AppendString, appends the string v to dst.
+func AppendString(dst []byte, v string) []byte {
+	n := len(v)
+	switch {
+	case n <= 31:
+		dst = append(dst, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		dst = append(dst, Str8, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, Str16), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, Str32), uint32(n))
+	}
+	return append(dst, v...)
+}
+
+// AppendBytes, appends the bytes v to dst as a bin value.
+func AppendBytes(dst []byte, v []byte) []byte {
+	n := len(v)
+	switch {
+	case n <= math.MaxUint8:
+		dst = append(dst, Bin8, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, Bin16), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, Bin32), uint32(n))
+	}
+	return append(dst, v...)
+}
+
+// AppendTime, appends the time v to dst using the timestamp extension type.
+// The smallest of the 32, 64 and 96-bit timestamp formats is used.
+func AppendTime(dst []byte, v time.Time) []byte {
+	sec, nsec := v.Unix(), uint32(v.Nanosecond())
+	if uint64(sec)>>34 == 0 {
+		data := uint64(nsec)<<34 | uint64(sec)
+		if data&0xffffffff00000000 == 0 {
+			dst = append(dst, FixExt4, 0xff)
+			return binary.BigEndian.AppendUint32(dst, uint32(data))
+		}
+		dst = append(dst, FixExt8, 0xff)
+		return binary.BigEndian.AppendUint64(dst, data)
+	}
+	dst = append(dst, Ext8, 12, 0xff)
+	dst = binary.BigEndian.AppendUint32(dst, nsec)
+	return binary.BigEndian.AppendUint64(dst, uint64(sec))
+}
+
+// IsNil, reports whether the next value of b is nil.
+func IsNil(b []byte) bool {
+	return len(b) > 0 && b[0] == Nil
+}
+
+// ReadNil, reads a nil value from b.
+func ReadNil(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, ErrShortBytes
+	}
+	if b[0] != Nil {
+		return b, &TypeError{Type: "nil", Format: b[0]}
+	}
+	return b[1:], nil
+}
+
+// ReadBool, reads a bool from b.
+func ReadBool(b []byte) (bool, []byte, error) {
+	if len(b) == 0 {
+		return false, b, ErrShortBytes
+	}
+	switch b[0] {
+	case True:
+		return true, b[1:], nil
+	case False:
+		return false, b[1:], nil
+	}
+	return false, b, &TypeError{Type: "bool", Format: b[0]}
+}
+
+// ReadInt, reads an int of any size or signedness from b.
+func ReadInt(b []byte) (int64, []byte, error) {
+	if len(b) == 0 {
+		return 0, b, ErrShortBytes
+	}
+	c := b[0]
+	switch {
+	case c <= 0x7f || c >= 0xe0: // positive and negative fixint
+		return int64(int8(c)), b[1:], nil
+	case c >= Uint8 && c <= Uint64:
+		u, rest, err := ReadUint(b)
+		if err == nil && u > math.MaxInt64 {
+			return 0, b, ErrRange
+		}
+		return int64(u), rest, err
+	}
+	n := 0
+	switch c {
+	case Int8:
+		n = 1
+	case Int16:
+		n = 2
+	case Int32:
+		n = 4
+	case Int64:
+		n = 8
+	default:
+		return 0, b, &TypeError{Type: "int", Format: c}
+	}
+	if len(b) < 1+n {
+		return 0, b, ErrShortBytes
+	}
+	var v int64
+	switch n {
+	case 1:
+		v = int64(int8(b[1]))
+	case 2:
+		v = int64(int16(binary.BigEndian.Uint16(b[1:])))
+	case 4:
+		v = int64(int32(binary.BigEndian.Uint32(b[1:])))
+	case 8:
+		v = int64(binary.BigEndian.Uint64(b[1:]))
+	}
+	return v, b[1+n:], nil
+}
+
+// ReadUint, reads an unsigned int from b. Signed ints are accepted if they
+// are not negative.
+func ReadUint(b []byte) (uint64, []byte, error) {
+	if len(b) == 0 {
+		return 0, b, ErrShortBytes
+	}
+	c := b[0]
+	n := 0
+	switch {
+	case c <= 0x7f:
+		return uint64(c), b[1:], nil
+	case c == Uint8:
+		n = 1
+	case c == Uint16:
+		n = 2
+	case c == Uint32:
+		n = 4
+	case c == Uint64:
+		n = 8
+	case c >= 0xe0 || (c >= Int8 && c <= Int64):
+		v, rest, err := ReadInt(b)
+		if err == nil && v < 0 {
+			return 0, b, ErrRange
+		}
+		return uint64(v), rest, err
+	default:
+		return 0, b, &TypeError{Type: "uint", Format: c}
+	}
+	if len(b) < 1+n {
+		return 0, b, ErrShortBytes
+	}
+	var v uint64
+	switch n {
+	case 1:
+		v = uint64(b[1])
+	case 2:
+		v = uint64(binary.BigEndian.Uint16(b[1:]))
+	case 4:
+		v = uint64(binary.BigEndian.Uint32(b[1:]))
+	case 8:
+		v = binary.BigEndian.Uint64(b[1:])
+	}
+	return v, b[1+n:], nil
+}
+
+// ReadFloat64, reads a float from b. Ints are converted to float64.
+func ReadFloat64(b []byte) (float64, []byte, error) {
+	if len(b) == 0 {
+		return 0, b, ErrShortBytes
+	}
+	switch b[0] {
+	case Float64:
+		if len(b) < 9 {
+			return 0, b, ErrShortBytes
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b[1:])), b[9:], nil
+	case Float32:
+		if len(b) < 5 {
+			return 0, b, ErrShortBytes
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b[1:]))), b[5:], nil
+	case Uint64:
+		u, rest, err := ReadUint(b)
+		return float64(u), rest, err
+	}
+	v, rest, err := ReadInt(b)
+	if err != nil {
+		if _, ok := err.(*TypeError); ok {
+			err = &TypeError{Type: "float", Format: b[0]}
+		}
+		return 0, b, err
+	}
+	return float64(v), rest, nil
+}
+
+// ReadFloat32, reads a float from b. Ints and float64 values are converted
+// to float32, ErrRange is returned if a float64 overflows a float32.
+func ReadFloat32(b []byte) (float32, []byte, error) {
+	v, rest, err := ReadFloat64(b)
+	if err != nil {
+		return 0, b, err
+	}
+	f := float32(v)
+	if math.IsInf(float64(f), 0) && !math.IsInf(v, 0) {
+		return 0, b, ErrRange
+	}
+	return f, rest, nil
+}
+
+// ReadStringBytes, reads a str or bin value from b and returns its bytes,
+// which alias b.
+func ReadStringBytes(b []byte) ([]byte, []byte, error) {
+	if len(b) == 0 {
+		return nil, b, ErrShortBytes
+	}
+	c := b[0]
+	var hdr, n int
+	switch {
+	case c >= 0xa0 && c <= 0xbf:
+		hdr, n = 1, int(c&0x1f)
+	case c == Str8 || c == Bin8:
+		if len(b) < 2 {
+			return nil, b, ErrShortBytes
+		}
+		hdr, n = 2, int(b[1])
+	case c == Str16 || c == Bin16:
+		if len(b) < 3 {
+			return nil, b, ErrShortBytes
+		}
+		hdr, n = 3, int(binary.BigEndian.Uint16(b[1:]))
+	case c == Str32 || c == Bin32:
+		if len(b) < 5 {
+			return nil, b, ErrShortBytes
+		}
+		hdr, n = 5, int(binary.BigEndian.Uint32(b[1:]))
+	default:
+		return nil, b, &TypeError{Type: "str", Format: c}
+	}
+	if n < 0 || len(b)-hdr < n {
+		return nil, b, ErrShortBytes
+	}
+	return b[hdr : hdr+n], b[hdr+n:], nil
+}
+
+// ReadString, reads a str or bin value from b.
+func ReadString(b []byte) (string, []byte, error) {
+	v, rest, err := ReadStringBytes(b)
+	return string(v), rest, err
+}
+
+// ReadBytes, reads a str or bin value from b and returns a copy of its bytes.
+func ReadBytes(b []byte) ([]byte, []byte, error) {
+	v, rest, err := ReadStringBytes(b)
+	if err != nil {
+		return nil, b, err
+	}
+	return append([]byte{}, v...), rest, nil
+}
+
+// ReadTime, reads a timestamp extension value from b. The time is returned
+// in UTC.
+func ReadTime(b []byte) (time.Time, []byte, error) {
+	if len(b) == 0 {
+		return time.Time{}, b, ErrShortBytes
+	}
+	var data []byte
+	switch b[0] {
+	case FixExt4:
+		if len(b) < 6 {
+			return time.Time{}, b, ErrShortBytes
+		}
+		data = b[2:6]
+	case FixExt8:
+		if len(b) < 10 {
+			return time.Time{}, b, ErrShortBytes
+		}
+		data = b[2:10]
+	case Ext8:
+		if len(b) < 3 {
+			return time.Time{}, b, ErrShortBytes
+		}
+		if b[1] != 12 {
+			return time.Time{}, b, &TypeError{Type: "timestamp", Format: b[0]}
+		}
+		if len(b) < 15 {
+			return time.Time{}, b, ErrShortBytes
+		}
+		if int8(b[2]) != TimestampType {
+			return time.Time{}, b, &TypeError{Type: "timestamp", Format: b[0]}
+		}
+		nsec := binary.BigEndian.Uint32(b[3:])
+		sec := int64(binary.BigEndian.Uint64(b[7:]))
+		if nsec >= 1e9 {
+			return time.Time{}, b, ErrRange
+		}
+		return time.Unix(sec, int64(nsec)).UTC(), b[15:], nil
+	default:
+		return time.Time{}, b, &TypeError{Type: "timestamp", Format: b[0]}
+	}
+	if int8(b[1]) != TimestampType {
+		return time.Time{}, b, &TypeError{Type: "timestamp", Format: b[0]}
+	}
+	rest := b[2+len(data):]
+	if len(data) == 4 {
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), rest, nil
+	}
+	v := binary.BigEndian.Uint64(data)
+	nsec := v >> 34
+	if nsec >= 1e9 {
+		return time.Time{}, b, ErrRange
+	}
+	return time.Unix(int64(v&(1<<34-1)), int64(nsec)).UTC(), rest, nil
+}
@@ -0,0 +1,152 @@
+package msgpack
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendInt(t *testing.T) {
+	tests := []struct {
+		in  int64
+		out []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{Uint8, 0x80}},
+		{256, []byte{Uint16, 0x01, 0x00}},
+		{65536, []byte{Uint32, 0x00, 0x01, 0x00, 0x00}},
+		{1 << 32, []byte{Uint64, 0, 0, 0, 1, 0, 0, 0, 0}},
+		{-1, []byte{0xff}},
+		{-32, []byte{0xe0}},
+		{-33, []byte{Int8, 0xdf}},
+		{-129, []byte{Int16, 0xff, 0x7f}},
+		{-32769, []byte{Int32, 0xff, 0xff, 0x7f, 0xff}},
+		{math.MinInt64, []byte{Int64, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, test := range tests {
+		b := AppendInt(nil, test.in)
+		if !bytes.Equal(b, test.out) {
+			t.Errorf("AppendInt(%d) = %#v, want %#v", test.in, b, test.out)
+		}
+		v, rest, err := ReadInt(append(b, 0xc0))
+		if err != nil || v != test.in || !bytes.Equal(rest, []byte{0xc0}) {
+			t.Errorf("ReadInt(%#v) = %d, %#v, %v want %d", b, v, rest, err, test.in)
+		}
+	}
+	if _, _, err := ReadInt(AppendUint(nil, math.MaxUint64)); err != ErrRange {
+		t.Errorf("ReadInt(MaxUint64): got error %v, want %v", err, ErrRange)
+	}
+	if _, _, err := ReadUint(AppendInt(nil, -1)); err != ErrRange {
+		t.Errorf("ReadUint(-1): got error %v, want %v", err, ErrRange)
+	}
+	if _, _, err := ReadInt([]byte{Int32, 0}); err != ErrShortBytes {
+		t.Errorf("ReadInt(short): got error %v, want %v", err, ErrShortBytes)
+	}
+	if _, _, err := ReadInt([]byte{True}); err == nil {
+		t.Error("ReadInt(true): expected error")
+	}
+}
+
+func TestFloat(t *testing.T) {
+	b := AppendFloat64(nil, 1.5)
+	if want := []byte{Float64, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}; !bytes.Equal(b, want) {
+		t.Errorf("AppendFloat64(1.5) = %#v, want %#v", b, want)
+	}
+	b = AppendFloat32(nil, 1.5)
+	if want := []byte{Float32, 0x3f, 0xc0, 0, 0}; !bytes.Equal(b, want) {
+		t.Errorf("AppendFloat32(1.5) = %#v, want %#v", b, want)
+	}
+	for _, b := range [][]byte{AppendFloat64(nil, 1.5), AppendFloat32(nil, 1.5)} {
+		if v, _, err := ReadFloat64(b); v != 1.5 || err != nil {
+			t.Errorf("ReadFloat64(%#v) = %v, %v", b, v, err)
+		}
+		if v, _, err := ReadFloat32(b); v != 1.5 || err != nil {
+			t.Errorf("ReadFloat32(%#v) = %v, %v", b, v, err)
+		}
+	}
+	if v, _, err := ReadFloat64(AppendInt(nil, -7)); v != -7 || err != nil {
+		t.Errorf("ReadFloat64(-7) = %v, %v", v, err)
+	}
+	if _, _, err := ReadFloat32(AppendFloat64(nil, math.MaxFloat64)); err != ErrRange {
+		t.Errorf("ReadFloat32(MaxFloat64): got error %v, want %v", err, ErrRange)
+	}
+	if _, _, err := ReadFloat64(AppendString(nil, "1")); err == nil {
+		t.Error("ReadFloat64(str): expected error")
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, n := range []int{0, 31, 32, 255, 256, 65535, 65536} {
+		s := strings.Repeat("x", n)
+		b := AppendString(nil, s)
+		v, rest, err := ReadString(b)
+		if err != nil || v != s || len(rest) != 0 {
+			t.Errorf("ReadString(AppendString(%d bytes)) = %d bytes, %v", n, len(v), err)
+		}
+		b = AppendBytes(nil, []byte(s))
+		v, rest, err = ReadString(b)
+		if err != nil || v != s || len(rest) != 0 {
+			t.Errorf("ReadString(AppendBytes(%d bytes)) = %d bytes, %v", n, len(v), err)
+		}
+	}
+	if b := AppendString(nil, "ab"); !bytes.Equal(b, []byte{0xa2, 'a', 'b'}) {
+		t.Errorf("AppendString(%q) = %#v", "ab", b)
+	}
+	if _, _, err := ReadString([]byte{0xa2, 'a'}); err != ErrShortBytes {
+		t.Errorf("ReadString(short): got error %v, want %v", err, ErrShortBytes)
+	}
+}
+
+func TestBoolNil(t *testing.T) {
+	b := AppendNil(AppendBool(AppendBool(nil, true), false))
+	v, b, err := ReadBool(b)
+	if !v || err != nil {
+		t.Errorf("ReadBool: got %v, %v want true", v, err)
+	}
+	v, b, err = ReadBool(b)
+	if v || err != nil {
+		t.Errorf("ReadBool: got %v, %v want false", v, err)
+	}
+	if !IsNil(b) {
+		t.Errorf("IsNil(%#v) = false", b)
+	}
+	if b, err = ReadNil(b); len(b) != 0 || err != nil {
+		t.Errorf("ReadNil: got %#v, %v", b, err)
+	}
+}
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		in  time.Time
+		out []byte
+	}{
+		{time.Unix(1, 0), []byte{FixExt4, 0xff, 0, 0, 0, 1}},
+		{time.Unix(1, 1), []byte{FixExt8, 0xff, 0, 0, 0, 0x04, 0, 0, 0, 1}},
+		{time.Unix(1<<34, 0), []byte{Ext8, 12, 0xff, 0, 0, 0, 0, 0, 0, 0, 0x04, 0, 0, 0, 0}},
+		{time.Unix(-1, 5), []byte{Ext8, 12, 0xff, 0, 0, 0, 5, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, test := range tests {
+		b := AppendTime(nil, test.in)
+		if !bytes.Equal(b, test.out) {
+			t.Errorf("AppendTime(%v) = %#v, want %#v", test.in, b, test.out)
+		}
+		v, rest, err := ReadTime(b)
+		if err != nil || !v.Equal(test.in) || v.Location() != time.UTC || len(rest) != 0 {
+			t.Errorf("ReadTime(%#v) = %v, %#v, %v want %v", b, v, rest, err, test.in)
+		}
+	}
+	for _, b := range [][]byte{
+		{FixExt4, 0x01, 0, 0, 0, 1},
+		{FixExt4, 0xff, 0, 0},
+		{FixExt8, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0},
+		{Ext8, 8, 0xff},
+		{Nil},
+	} {
+		if _, _, err := ReadTime(b); err == nil {
+			t.Errorf("ReadTime(%#v): expected error", b)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package null
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+type msgpackCodec interface {
+	AppendMsgpack(dst []byte) ([]byte, error)
+}
+
+func TestMsgpack(t *testing.T) {
+	tests := []struct {
+		in  msgpackCodec
+		out []byte
+	}{
+		{Int{}, []byte{0xc0}},
+		{NewInt(-1), []byte{0xff}},
+		{NewInt(1000), []byte{0xcd, 0x03, 0xe8}},
+		{Uint64{}, []byte{0xc0}},
+		{NewUint64(1), []byte{0x01}},
+		{NewUint64(math.MaxUint64), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{Float64{}, []byte{0xc0}},
+		{NewFloat64(1.5), []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{Float32{}, []byte{0xc0}},
+		{NewFloat32(1.5), []byte{0xca, 0x3f, 0xc0, 0, 0}},
+		{String{}, []byte{0xc0}},
+		{NewString("ab"), []byte{0xa2, 'a', 'b'}},
+		{UnescapedString{}, []byte{0xc0}},
+		{NewUnescapedString("<"), []byte{0xa1, '<'}},
+		{Bool{}, []byte{0xc0}},
+		{NewBool(true), []byte{0xc3}},
+		{Time{}, []byte{0xc0}},
+		{NewTime(time.Unix(1, 0).UTC()), []byte{0xd6, 0xff, 0, 0, 0, 1}},
+	}
+	for _, test := range tests {
+		b, err := test.in.AppendMsgpack([]byte{0x01})
+		if err != nil {
+			t.Errorf("AppendMsgpack(%+v): %v", test.in, err)
+			continue
+		}
+		if !bytes.Equal(b[1:], test.out) {
+			t.Errorf("AppendMsgpack(%+v) = %#v, want %#v", test.in, b[1:], test.out)
+		}
+		v := newTextUnmarshaler(test.in)
+		rest, err := v.(interface {
+			DecodeMsgpack([]byte) ([]byte, error)
+		}).DecodeMsgpack(append(b[1:], 0x02))
+		if err != nil {
+			t.Errorf("%T: DecodeMsgpack(%#v): %v", test.in, b[1:], err)
+			continue
+		}
+		if !bytes.Equal(rest, []byte{0x02}) {
+			t.Errorf("%T: DecodeMsgpack(%#v): remaining bytes = %#v", test.in, b[1:], rest)
+		}
+		if !textEqual(v, test.in) {
+			t.Errorf("%T: DecodeMsgpack(%#v) = %+v, want %+v", test.in, b[1:], v, test.in)
+		}
+	}
+}
+
+func TestDecodeMsgpackErrors(t *testing.T) {
+	var i Int
+	if _, err := i.DecodeMsgpack([]byte{0xa1, 'x'}); err == nil || i.Valid {
+		t.Errorf("Int: DecodeMsgpack(str): got %+v, %v want error", i, err)
+	}
+	var u Uint64
+	if _, err := u.DecodeMsgpack([]byte{0xff}); err == nil || u.Valid {
+		t.Errorf("Uint64: DecodeMsgpack(-1): got %+v, %v want error", u, err)
+	}
+	var s String
+	if _, err := s.DecodeMsgpack([]byte{0x01}); err == nil || s.Valid {
+		t.Errorf("String: DecodeMsgpack(int): got %+v, %v want error", s, err)
+	}
+	var tm Time
+	if _, err := tm.DecodeMsgpack([]byte{0xa1, 'x'}); err == nil || tm.Valid {
+		t.Errorf("Time: DecodeMsgpack(str): got %+v, %v want error", tm, err)
+	}
+	var b Bool
+	if _, err := b.DecodeMsgpack(nil); err == nil || b.Valid {
+		t.Errorf("Bool: DecodeMsgpack(nil): got %+v, %v want error", b, err)
+	}
+}
@@ -7,17 +7,24 @@ package null
 
 import (
 	"bytes"
-	"database/sql"
 	"database/sql/driver"
-	"errors"
 	"strconv"
 	"time"
-
-	"github.com/go-sql-driver/mysql"
 )
 
 var nullLiteral = []byte("null")
 
+// ValidZeroIsZero, controls whether IsZero also reports true for valid values
+// that hold the zero value of their type (0, "", false or the zero time.Time).
+// By default only invalid (NULL) values are zero.
+//
+// IsZero is used by the omitzero struct tag option of encoding/json (Go 1.24
+// and later) and encoding/json/v2. The omitempty option never omits the null
+// types since they are structs.
+//
+// ValidZeroIsZero should only be changed during program initialization.
+var ValidZeroIsZero = false
+
 // A Int is a nullable int that can be scanned into and from databases,
 // and marshaled into and from JSON.
 type Int struct {
@@ -52,7 +59,7 @@ func (i *Int) Scan(value interface{}) error {
 	n, err := convertInt(value, strconv.IntSize)
 	if err != nil {
 		i.Int, i.Valid = 0, false
-		return err
+		return newScanError("null.Int", value, err)
 	}
 	i.Int = int(n)
 	i.Valid = true
@@ -70,23 +77,23 @@ func (i Int) Value() (driver.Value, error) {
 // MarshalJSON, marshals Int i into JSON.
 func (i Int) MarshalJSON() ([]byte, error) {
 	if i.Valid {
-		return strconv.AppendInt(nil, int64(i.Int), 10), nil
+		return i.AppendJSON(make([]byte, 0, 20))
 	}
 	return nullLiteral, nil
 }
 
-// MarshalJSON, unmarshals JSON data into Int i.
-func (i *Int) UnmarshalJSON(data []byte) (err error) {
-	if null(data) {
-		i.Int, i.Valid = 0, false
-		return nil
-	}
-	n, err := parseInt(unquote(data), strconv.IntSize)
-	if err == nil {
-		i.Int = int(n)
+// AppendJSON, appends the JSON encoding of Int i to dst.
+func (i Int) AppendJSON(dst []byte) ([]byte, error) {
+	if i.Valid {
+		return strconv.AppendInt(dst, int64(i.Int), 10), nil
 	}
-	i.Valid = (err == nil)
-	return err
+	return append(dst, nullLiteral...), nil
+}
+
+// UnmarshalJSON, unmarshals JSON data into Int i using the Mode of
+// DefaultDecoder.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	return DefaultDecoder.UnmarshalInt(data, i)
 }
 
 // Ptr, returns the value of Int i as a pointer.
@@ -98,6 +105,106 @@ func (i Int) Ptr() *int {
 	return &n
 }
 
+// IsZero, reports whether Int i is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (i Int) IsZero() bool {
+	return !i.Valid || (ValidZeroIsZero && i.Int == 0)
+}
+
+// A Uint64 is a nullable uint64 that can be scanned into and from databases,
+// and marshaled into and from JSON. It holds the full range of unsigned
+// BIGINT columns.
+type Uint64 struct {
+	Uint64 uint64
+	Valid  bool
+}
+
+// NewUint64, returns a new valid Uint64.
+func NewUint64(u uint64) Uint64 {
+	return Uint64{
+		Uint64: u,
+		Valid:  true,
+	}
+}
+
+// PtrUint64, returns a new Uint64 from a pointer.
+func PtrUint64(u *uint64) Uint64 {
+	if u == nil {
+		return Uint64{Valid: false}
+	}
+	return Uint64{
+		Uint64: *u,
+		Valid:  true,
+	}
+}
+
+// Scan, scans a database value into Uint64 u.
+func (u *Uint64) Scan(value interface{}) error {
+	if value == nil {
+		u.Uint64, u.Valid = 0, false
+		return nil
+	}
+	n, err := convertUint(value, 64)
+	if err != nil {
+		u.Uint64, u.Valid = 0, false
+		return newScanError("null.Uint64", value, err)
+	}
+	u.Uint64, u.Valid = n, true
+	return nil
+}
+
+// Value, returns the database driver value of Uint64 u. Since database/sql
+// does not accept uint64 driver values, values greater than math.MaxInt64 are
+// returned as a decimal string, which databases convert to the column type.
+// Drivers that accept uint64 values may use CheckNamedValue or Converter to
+// receive a uint64 instead.
+func (u Uint64) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	if u.Uint64 > maxInt64 {
+		return strconv.FormatUint(u.Uint64, 10), nil
+	}
+	return int64(u.Uint64), nil
+}
+
+// MarshalJSON, marshals Uint64 u into JSON.
+func (u Uint64) MarshalJSON() ([]byte, error) {
+	if u.Valid {
+		return u.AppendJSON(make([]byte, 0, 20))
+	}
+	return nullLiteral, nil
+}
+
+// AppendJSON, appends the JSON encoding of Uint64 u to dst.
+func (u Uint64) AppendJSON(dst []byte) ([]byte, error) {
+	if u.Valid {
+		return strconv.AppendUint(dst, u.Uint64, 10), nil
+	}
+	return append(dst, nullLiteral...), nil
+}
+
+// UnmarshalJSON, unmarshals JSON data into Uint64 u using the Mode of
+// DefaultDecoder.
+func (u *Uint64) UnmarshalJSON(data []byte) error {
+	return DefaultDecoder.UnmarshalUint64(data, u)
+}
+
+// Ptr, returns the value of Uint64 u as a pointer.
+func (u Uint64) Ptr() *uint64 {
+	if !u.Valid {
+		return nil
+	}
+	n := u.Uint64
+	return &n
+}
+
+// IsZero, reports whether Uint64 u is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (u Uint64) IsZero() bool {
+	return !u.Valid || (ValidZeroIsZero && u.Uint64 == 0)
+}
+
 // A Float64 is a nullable float64 that can be scanned into and from databases,
 // and marshaled into and from JSON.
 type Float64 struct {
@@ -133,7 +240,7 @@ func (f *Float64) Scan(value interface{}) error {
 	ff, err := convertFloat(value, 64)
 	if err != nil {
 		f.Float64, f.Valid = 0, false
-		return err
+		return newScanError("null.Float64", value, err)
 	}
 	f.Float64, f.Valid = ff, err == nil
 	return nil
@@ -150,20 +257,23 @@ func (f Float64) Value() (driver.Value, error) {
 // MarshalJSON, marshals Float64 f into JSON.
 func (f Float64) MarshalJSON() ([]byte, error) {
 	if f.Valid {
-		return encodeFloat(f.Float64, 64)
+		return f.AppendJSON(make([]byte, 0, 24))
 	}
 	return nullLiteral, nil
 }
 
-// UnmarshalJSON, unmarshals JSON data into Flaot64 f.
-func (f *Float64) UnmarshalJSON(data []byte) (err error) {
-	if null(data) {
-		f.Float64, f.Valid = 0, false
-		return nil
+// AppendJSON, appends the JSON encoding of Float64 f to dst.
+func (f Float64) AppendJSON(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return appendFloat(dst, f.Float64, 64)
 	}
-	f.Float64, err = strconv.ParseFloat(string(unquote(data)), 64)
-	f.Valid = (err == nil)
-	return err
+	return append(dst, nullLiteral...), nil
+}
+
+// UnmarshalJSON, unmarshals JSON data into Float64 f using the Mode of
+// DefaultDecoder.
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	return DefaultDecoder.UnmarshalFloat64(data, f)
 }
 
 // Ptr, returns the value of Float64 f as a pointer.
@@ -175,6 +285,12 @@ func (f Float64) Ptr() *float64 {
 	return &n
 }
 
+// IsZero, reports whether Float64 f is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (f Float64) IsZero() bool {
+	return !f.Valid || (ValidZeroIsZero && f.Float64 == 0)
+}
+
 // A Float32 is a nullable float32 that can be scanned into and from databases,
 // and marshaled into and from JSON.
 type Float32 struct {
@@ -210,7 +326,7 @@ func (f *Float32) Scan(value interface{}) error {
 	ff, err := convertFloat(value, 32)
 	if err != nil {
 		f.Float32, f.Valid = 0, false
-		return err
+		return newScanError("null.Float32", value, err)
 	}
 	f.Float32, f.Valid = float32(ff), err == nil
 	return nil
@@ -227,22 +343,23 @@ func (f Float32) Value() (driver.Value, error) {
 // MarshalJSON, marshals Float32 f into JSON.
 func (f Float32) MarshalJSON() ([]byte, error) {
 	if f.Valid {
-		return encodeFloat(float64(f.Float32), 32)
+		return f.AppendJSON(make([]byte, 0, 16))
 	}
 	return nullLiteral, nil
 }
 
-// UnmarshalJSON, unmarshals JSON data into Flaot64 f.
-func (f *Float32) UnmarshalJSON(data []byte) (err error) {
-	if null(data) {
-		f.Float32, f.Valid = 0, false
-		return nil
+// AppendJSON, appends the JSON encoding of Float32 f to dst.
+func (f Float32) AppendJSON(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return appendFloat(dst, float64(f.Float32), 32)
 	}
-	var ff float64
-	ff, err = strconv.ParseFloat(string(unquote(data)), 64)
-	f.Valid = (err == nil)
-	f.Float32 = float32(ff)
-	return err
+	return append(dst, nullLiteral...), nil
+}
+
+// UnmarshalJSON, unmarshals JSON data into Float32 f using the Mode of
+// DefaultDecoder.
+func (f *Float32) UnmarshalJSON(data []byte) error {
+	return DefaultDecoder.UnmarshalFloat32(data, f)
 }
 
 // Ptr, returns the value of Float32 f as a pointer.
@@ -254,6 +371,12 @@ func (f Float32) Ptr() *float32 {
 	return &n
 }
 
+// IsZero, reports whether Float32 f is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (f Float32) IsZero() bool {
+	return !f.Valid || (ValidZeroIsZero && f.Float32 == 0)
+}
+
 // A String is a nullable string that can be scanned into and from databases,
 // and marshaled into and from JSON.
 type String struct {
@@ -282,10 +405,17 @@ func PtrString(s *string) String {
 
 // Scan, scans value into String s.
 func (s *String) Scan(value interface{}) error {
-	var n sql.NullString
-	err := n.Scan(value)
-	s.String, s.Valid = n.String, n.Valid
-	return err
+	if value == nil {
+		s.String, s.Valid = "", false
+		return nil
+	}
+	str, err := DefaultTimePolicy.convertString(value)
+	if err != nil {
+		s.String, s.Valid = "", false
+		return newScanError("null.String", value, err)
+	}
+	s.String, s.Valid = str, true
+	return nil
 }
 
 // Value, returns the database driver value of String s.
@@ -296,23 +426,24 @@ func (s String) Value() (driver.Value, error) {
 	return nil, nil
 }
 
-// MarshalJSON, marshals String s into JSON.
+// MarshalJSON, marshals String s into JSON using DefaultEncoder.
 func (s String) MarshalJSON() ([]byte, error) {
 	if s.Valid {
-		return marshalString(s.String)
+		return s.AppendJSON(make([]byte, 0, len(s.String)+2))
 	}
 	return nullLiteral, nil
 }
 
-// UnmarshalJSON, unmarshals JSON data into String s.
-func (s *String) UnmarshalJSON(data []byte) (err error) {
-	if null(data) {
-		s.String, s.Valid = "", false
-		return nil
-	}
-	s.String, err = unmarshalString(data)
-	s.Valid = (err == nil)
-	return err
+// AppendJSON, appends the JSON encoding of String s to dst using
+// DefaultEncoder.
+func (s String) AppendJSON(dst []byte) ([]byte, error) {
+	return DefaultEncoder.AppendString(dst, s)
+}
+
+// UnmarshalJSON, unmarshals JSON data into String s using the Mode of
+// DefaultDecoder.
+func (s *String) UnmarshalJSON(data []byte) error {
+	return DefaultDecoder.UnmarshalString(data, s)
 }
 
 // Ptr, returns the value of String s as a pointer.
@@ -324,6 +455,74 @@ func (s String) Ptr() *string {
 	return &n
 }
 
+// IsZero, reports whether String s is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (s String) IsZero() bool {
+	return !s.Valid || (ValidZeroIsZero && s.String == "")
+}
+
+// An UnescapedString is a String that does not escape the HTML characters
+// <, > and & when marshaled into JSON, regardless of DefaultEncoder.
+//
+// Note that json.Marshal escapes HTML in the output of MarshalJSON, use a
+// json.Encoder with SetEscapeHTML(false) to preserve the unescaped output.
+type UnescapedString String
+
+// NewUnescapedString, returns a new valid UnescapedString with value s.
+func NewUnescapedString(s string) UnescapedString {
+	return UnescapedString{
+		String: s,
+		Valid:  true,
+	}
+}
+
+// PtrUnescapedString, returns a new UnescapedString from a pointer.
+func PtrUnescapedString(s *string) UnescapedString {
+	return UnescapedString(PtrString(s))
+}
+
+// Scan, scans value into UnescapedString s.
+func (s *UnescapedString) Scan(value interface{}) error {
+	return (*String)(s).Scan(value)
+}
+
+// Value, returns the database driver value of UnescapedString s.
+func (s UnescapedString) Value() (driver.Value, error) {
+	return String(s).Value()
+}
+
+// MarshalJSON, marshals UnescapedString s into JSON without escaping HTML.
+func (s UnescapedString) MarshalJSON() ([]byte, error) {
+	if s.Valid {
+		return s.AppendJSON(make([]byte, 0, len(s.String)+2))
+	}
+	return nullLiteral, nil
+}
+
+// AppendJSON, appends the JSON encoding of UnescapedString s to dst without
+// escaping HTML.
+func (s UnescapedString) AppendJSON(dst []byte) ([]byte, error) {
+	e := DefaultEncoder
+	e.EscapeHTML = false
+	return e.AppendString(dst, String(s))
+}
+
+// UnmarshalJSON, unmarshals JSON data into UnescapedString s.
+func (s *UnescapedString) UnmarshalJSON(data []byte) error {
+	return (*String)(s).UnmarshalJSON(data)
+}
+
+// Ptr, returns the value of UnescapedString s as a pointer.
+func (s UnescapedString) Ptr() *string {
+	return String(s).Ptr()
+}
+
+// IsZero, reports whether UnescapedString s is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (s UnescapedString) IsZero() bool {
+	return String(s).IsZero()
+}
+
 // A Bool is a nullable bool that can be scanned into and from databases,
 // and marshaled into and from JSON.
 type Bool struct {
@@ -352,10 +551,17 @@ func PtrBool(b *bool) Bool {
 
 // Scan, scans a database value into Bool b.
 func (b *Bool) Scan(value interface{}) error {
-	var n sql.NullBool
-	err := n.Scan(value)
-	b.Bool, b.Valid = n.Bool, n.Valid
-	return err
+	if value == nil {
+		b.Bool, b.Valid = false, false
+		return nil
+	}
+	v, err := convertBool(value)
+	if err != nil {
+		b.Bool, b.Valid = false, false
+		return newScanError("null.Bool", value, err)
+	}
+	b.Bool, b.Valid = v, true
+	return nil
 }
 
 // Value, returns the database driver value of Bool b.
@@ -368,31 +574,24 @@ func (b Bool) Value() (driver.Value, error) {
 
 // MarshalJSON, marshals Bool b into JSON.
 func (b Bool) MarshalJSON() ([]byte, error) {
+	return b.AppendJSON(make([]byte, 0, 7))
+}
+
+// AppendJSON, appends the JSON encoding of Bool b to dst.
+func (b Bool) AppendJSON(dst []byte) ([]byte, error) {
 	if b.Valid {
 		if b.Bool {
-			return []byte(`"true"`), nil
+			return append(dst, `"true"`...), nil
 		}
-		return []byte(`"false"`), nil
+		return append(dst, `"false"`...), nil
 	}
-	return nullLiteral, nil
+	return append(dst, nullLiteral...), nil
 }
 
-// UnmarshalJSON, unmarshals JSON data into Bool b.
-func (b *Bool) UnmarshalJSON(data []byte) (err error) {
-	if null(data) {
-		b.Bool, b.Valid = false, false
-		return nil
-	}
-	data = unquote(data)
-	switch s := string(data); s {
-	case "true":
-		b.Bool, b.Valid = true, true
-	case "false":
-		b.Bool, b.Valid = false, true
-	default:
-		err = errors.New("null: cannot unmarshal '" + s + "' into type Bool")
-	}
-	return err
+// UnmarshalJSON, unmarshals JSON data into Bool b using the Mode of
+// DefaultDecoder.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	return DefaultDecoder.UnmarshalBool(data, b)
 }
 
 // Ptr, returns the value of Bool t as a pointer.
@@ -404,6 +603,12 @@ func (b Bool) Ptr() *bool {
 	return &n
 }
 
+// IsZero, reports whether Bool b is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (b Bool) IsZero() bool {
+	return !b.Valid || (ValidZeroIsZero && !b.Bool)
+}
+
 // A Time is a nullable time.Time that can be scanned into and from databases,
 // and marshaled into and from JSON.
 type Time struct {
@@ -430,41 +635,36 @@ func PtrTime(t *time.Time) Time {
 	}
 }
 
-// Scan, scans a database value into Time t.
+// Scan, scans a database value into Time t. The scanned time is normalized
+// by DefaultTimePolicy.
 func (t *Time) Scan(value interface{}) error {
-	var n mysql.NullTime
-	err := n.Scan(value)
-	t.Time, t.Valid = n.Time, n.Valid
-	return err
+	return DefaultTimePolicy.ScanTime(value, t)
 }
 
-// Value, returns the database driver value of Time t.
+// Value, returns the database driver value of Time t. The time is normalized
+// by DefaultTimePolicy.
 func (t Time) Value() (driver.Value, error) {
-	if t.Valid {
-		return t.Time, nil
-	}
-	return nil, nil
+	return DefaultTimePolicy.TimeValue(t)
 }
 
-// MarshalJSON implements the json.Marshaler interface. The time is a quoted
-// string in RFC 3339 format, with sub-second precision added if present.
+// MarshalJSON implements the json.Marshaler interface. The time is encoded
+// using the TimeFormat of DefaultEncoder, by default a quoted string in
+// RFC 3339 format, with sub-second precision added if present.
 func (t Time) MarshalJSON() ([]byte, error) {
-	if t.Valid {
-		return t.Time.MarshalJSON()
-	}
-	return nullLiteral, nil
+	return DefaultEncoder.MarshalTime(t)
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface. The time is expected
-// to be a quoted string in RFC 3339 format.
-func (t *Time) UnmarshalJSON(data []byte) (err error) {
-	if null(data) {
-		t.Time, t.Valid = time.Time{}, false
-		return nil
-	}
-	err = t.Time.UnmarshalJSON(data)
-	t.Valid = (err == nil)
-	return err
+// AppendJSON, appends the JSON encoding of Time t to dst, see MarshalJSON
+// for the format.
+func (t Time) AppendJSON(dst []byte) ([]byte, error) {
+	return DefaultEncoder.AppendTime(dst, t)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The time is
+// expected to be in one of the TimeFormats of DefaultDecoder, by default a
+// quoted string in RFC 3339 format.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	return DefaultDecoder.UnmarshalTime(data, t)
 }
 
 // Ptr, returns the value of Time t as a pointer.
@@ -476,6 +676,12 @@ func (t Time) Ptr() *time.Time {
 	return &n
 }
 
+// IsZero, reports whether Time t is invalid (NULL) or, if
+// ValidZeroIsZero is set, holds the zero value.
+func (t Time) IsZero() bool {
+	return !t.Valid || (ValidZeroIsZero && t.Time.IsZero())
+}
+
 // Now, sets t's time to now.
 func (t *Time) Now() {
 	t.Time, t.Valid = time.Now(), true
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"math"
 	"testing"
 	"time"
 )
@@ -562,6 +563,138 @@ func TestString(t *testing.T) {
 	}
 }
 
+// A jsonAppender is implemented by all null types.
+type jsonAppender interface {
+	json.Marshaler
+	AppendJSON(dst []byte) ([]byte, error)
+}
+
+var appendJSONTests = []jsonAppender{
+	Int{}, NewInt(-123456),
+	Float64{}, NewFloat64(123.456), NewFloat64(1e-7),
+	Float32{}, NewFloat32(123.456),
+	String{}, NewString("a <b> \"c\""),
+	UnescapedString{}, NewUnescapedString("a <b> \"c\""),
+	Bool{}, NewBool(true), NewBool(false),
+	Time{}, NewTime(time.Date(2017, 10, 20, 23, 4, 56, 123456000, time.UTC)),
+}
+
+func TestAppendJSON(t *testing.T) {
+	const prefix = "prefix:"
+	for _, v := range appendJSONTests {
+		want, err := v.MarshalJSON()
+		if err != nil {
+			t.Errorf("%T(%+v).MarshalJSON: %v", v, v, err)
+			continue
+		}
+		b, err := v.AppendJSON([]byte(prefix))
+		if err != nil {
+			t.Errorf("%T(%+v).AppendJSON: %v", v, v, err)
+			continue
+		}
+		if string(b) != prefix+string(want) {
+			t.Errorf("%T(%+v).AppendJSON = %q, want %q", v, v, b, prefix+string(want))
+		}
+	}
+}
+
+func TestAppendJSONAllocs(t *testing.T) {
+	buf := make([]byte, 0, 128)
+	for _, v := range appendJSONTests {
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := v.AppendJSON(buf[:0]); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("%T(%+v).AppendJSON: got %v allocs, want 0", v, v, allocs)
+		}
+	}
+}
+
+func TestAppendJSONErrors(t *testing.T) {
+	tests := []jsonAppender{
+		NewFloat64(math.NaN()),
+		NewFloat64(math.Inf(1)),
+		NewFloat32(float32(math.Inf(-1))),
+		NewTime(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)),
+		NewTime(time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	for _, v := range tests {
+		if _, err := v.AppendJSON(nil); err == nil {
+			t.Errorf("%T(%+v).AppendJSON: expected error", v, v)
+		}
+		if _, err := v.MarshalJSON(); err == nil {
+			t.Errorf("%T(%+v).MarshalJSON: expected error", v, v)
+		}
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	type isZeroer interface {
+		IsZero() bool
+	}
+	invalid := []isZeroer{Int{}, Float64{}, Float32{}, String{}, UnescapedString{}, Bool{}, Time{}}
+	zero := []isZeroer{NewInt(0), NewFloat64(0), NewFloat32(0), NewString(""),
+		NewUnescapedString(""), NewBool(false), NewTime(time.Time{})}
+	nonzero := []isZeroer{NewInt(1), NewFloat64(1), NewFloat32(1), NewString("a"),
+		NewUnescapedString("a"), NewBool(true), NewTime(time.Now())}
+
+	defer func() { ValidZeroIsZero = false }()
+	for _, validZero := range []bool{false, true} {
+		ValidZeroIsZero = validZero
+		for _, v := range invalid {
+			if !v.IsZero() {
+				t.Errorf("%T(%+v).IsZero() = false, want true", v, v)
+			}
+		}
+		for _, v := range zero {
+			if v.IsZero() != validZero {
+				t.Errorf("ValidZeroIsZero(%t): %T(%+v).IsZero() = %t, want %t",
+					validZero, v, v, v.IsZero(), validZero)
+			}
+		}
+		for _, v := range nonzero {
+			if v.IsZero() {
+				t.Errorf("%T(%+v).IsZero() = true, want false", v, v)
+			}
+		}
+	}
+}
+
+func TestOmitZero(t *testing.T) {
+	type omitZero struct {
+		Int     Int     `json:"int,omitzero"`
+		Float64 Float64 `json:"float64,omitzero"`
+		Float32 Float32 `json:"float32,omitzero"`
+		String  String  `json:"string,omitzero"`
+		Bool    Bool    `json:"bool,omitzero"`
+		Time    Time    `json:"time,omitzero"`
+	}
+	defer func() { ValidZeroIsZero = false }()
+	tests := []struct {
+		v         omitZero
+		validZero bool
+		want      string
+	}{
+		{omitZero{}, false, `{}`},
+		{omitZero{Int: NewInt(0), String: NewString("")}, false, `{"int":0,"string":""}`},
+		{omitZero{Int: NewInt(0), String: NewString("")}, true, `{}`},
+		{omitZero{Int: NewInt(1), Bool: NewBool(false)}, true, `{"int":1}`},
+	}
+	for _, test := range tests {
+		ValidZeroIsZero = test.validZero
+		b, err := json.Marshal(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != test.want {
+			t.Errorf("ValidZeroIsZero(%t): Marshal(%+v) = %s, want %s",
+				test.validZero, test.v, b, test.want)
+		}
+	}
+}
+
 // Scan
 
 func BenchmarkIntScan_Int64(b *testing.B) {
@@ -659,6 +792,53 @@ func BenchmarkTimeMarshalJSON(b *testing.B) {
 	}
 }
 
+// AppendJSON
+
+func BenchmarkIntAppendJSON(b *testing.B) {
+	v := Int{123456, true}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkFloat64AppendJSON(b *testing.B) {
+	v := Float64{123.456, true}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkBoolAppendJSON(b *testing.B) {
+	v := Bool{true, true}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkStringAppendJSON(b *testing.B) {
+	v := String{"value string", true}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkTimeAppendJSON(b *testing.B) {
+	v := Time{time.Now(), true}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.AppendJSON(buf[:0])
+	}
+}
+
 // UnmarshalJSON
 
 func BenchmarkIntUnmarshalJSON(b *testing.B) {
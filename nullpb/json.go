@@ -0,0 +1,221 @@
+package nullpb
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/charlievieth/null"
+)
+
+var nullLiteral = []byte("null")
+
+// jsonDecoder, is the Decoder used for the values of protojson, which
+// always uses the default rules regardless of the null.DefaultDecoder.
+var jsonDecoder null.Decoder
+
+// jsonEncoder, is the Encoder used for the strings of protojson, which like
+// protojson does not escape HTML characters or line separators regardless of
+// the null.DefaultEncoder.
+var jsonEncoder null.Encoder
+
+func isNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), nullLiteral)
+}
+
+// AppendInt64ValueJSON, appends the protojson encoding of the
+// google.protobuf.Int64Value of Int v, a quoted decimal string, to dst.
+func AppendInt64ValueJSON(dst []byte, v null.Int) []byte {
+	if !v.Valid {
+		return append(dst, nullLiteral...)
+	}
+	dst = append(dst, '"')
+	dst = strconv.AppendInt(dst, int64(v.Int), 10)
+	return append(dst, '"')
+}
+
+// UnmarshalInt64ValueJSON, parses the protojson encoding of a
+// google.protobuf.Int64Value, either a JSON number or string.
+func UnmarshalInt64ValueJSON(data []byte) (null.Int, error) {
+	var v null.Int
+	err := jsonDecoder.UnmarshalInt(bytes.TrimSpace(data), &v)
+	return v, err
+}
+
+// appendFloatJSON, appends the protojson encoding of float f, which encodes
+// NaN and infinities as the strings "NaN", "Infinity" and "-Infinity".
+func appendFloatJSON(dst []byte, f float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(dst, `"NaN"`...)
+	case math.IsInf(f, 1):
+		return append(dst, `"Infinity"`...)
+	case math.IsInf(f, -1):
+		return append(dst, `"-Infinity"`...)
+	}
+	var b []byte
+	var err error
+	if bitSize == 32 {
+		b, err = null.NewFloat32(float32(f)).AppendJSON(dst)
+	} else {
+		b, err = null.NewFloat64(f).AppendJSON(dst)
+	}
+	if err != nil {
+		panic("nullpb: " + err.Error()) // unreachable: f is finite
+	}
+	return b
+}
+
+// parseFloatJSON, parses the protojson encoding of a float, a JSON number or
+// a string holding a number, "NaN", "Infinity" or "-Infinity".
+func parseFloatJSON(data []byte, bitSize int) (float64, error) {
+	data = bytes.TrimSpace(data)
+	switch string(data) {
+	case `"NaN"`:
+		return math.NaN(), nil
+	case `"Infinity"`:
+		return math.Inf(1), nil
+	case `"-Infinity"`:
+		return math.Inf(-1), nil
+	}
+	if bitSize == 32 {
+		var v null.Float32
+		err := jsonDecoder.UnmarshalFloat32(data, &v)
+		return float64(v.Float32), err
+	}
+	var v null.Float64
+	err := jsonDecoder.UnmarshalFloat64(data, &v)
+	return v.Float64, err
+}
+
+// AppendDoubleValueJSON, appends the protojson encoding of the
+// google.protobuf.DoubleValue of Float64 v to dst.
+func AppendDoubleValueJSON(dst []byte, v null.Float64) []byte {
+	if !v.Valid {
+		return append(dst, nullLiteral...)
+	}
+	return appendFloatJSON(dst, v.Float64, 64)
+}
+
+// UnmarshalDoubleValueJSON, parses the protojson encoding of a
+// google.protobuf.DoubleValue.
+func UnmarshalDoubleValueJSON(data []byte) (null.Float64, error) {
+	if isNull(data) {
+		return null.Float64{}, nil
+	}
+	f, err := parseFloatJSON(data, 64)
+	if err != nil {
+		return null.Float64{}, err
+	}
+	return null.NewFloat64(f), nil
+}
+
+// AppendFloatValueJSON, appends the protojson encoding of the
+// google.protobuf.FloatValue of Float32 v to dst.
+func AppendFloatValueJSON(dst []byte, v null.Float32) []byte {
+	if !v.Valid {
+		return append(dst, nullLiteral...)
+	}
+	return appendFloatJSON(dst, float64(v.Float32), 32)
+}
+
+// UnmarshalFloatValueJSON, parses the protojson encoding of a
+// google.protobuf.FloatValue.
+func UnmarshalFloatValueJSON(data []byte) (null.Float32, error) {
+	if isNull(data) {
+		return null.Float32{}, nil
+	}
+	f, err := parseFloatJSON(data, 32)
+	if err != nil {
+		return null.Float32{}, err
+	}
+	return null.NewFloat32(float32(f)), nil
+}
+
+// AppendStringValueJSON, appends the protojson encoding of the
+// google.protobuf.StringValue of String v to dst. Only quotes, backslashes
+// and control characters are escaped, like protojson escapes them.
+func AppendStringValueJSON(dst []byte, v null.String) []byte {
+	dst, _ = jsonEncoder.AppendString(dst, v)
+	return dst
+}
+
+// UnmarshalStringValueJSON, parses the protojson encoding of a
+// google.protobuf.StringValue.
+func UnmarshalStringValueJSON(data []byte) (null.String, error) {
+	var v null.String
+	err := jsonDecoder.UnmarshalString(bytes.TrimSpace(data), &v)
+	return v, err
+}
+
+// AppendBoolValueJSON, appends the protojson encoding of the
+// google.protobuf.BoolValue of Bool v, a JSON boolean, to dst.
+func AppendBoolValueJSON(dst []byte, v null.Bool) []byte {
+	if !v.Valid {
+		return append(dst, nullLiteral...)
+	}
+	return strconv.AppendBool(dst, v.Bool)
+}
+
+// UnmarshalBoolValueJSON, parses the protojson encoding of a
+// google.protobuf.BoolValue, which must be a JSON boolean.
+func UnmarshalBoolValueJSON(data []byte) (null.Bool, error) {
+	switch string(bytes.TrimSpace(data)) {
+	case "null":
+		return null.Bool{}, nil
+	case "true":
+		return null.NewBool(true), nil
+	case "false":
+		return null.NewBool(false), nil
+	}
+	return null.Bool{}, errors.New("nullpb: invalid BoolValue: " + string(data))
+}
+
+// AppendTimestampJSON, appends the protojson encoding of the
+// google.protobuf.Timestamp of Time v to dst, a RFC 3339 string in UTC with
+// 0, 3, 6 or 9 fractional second digits.
+func AppendTimestampJSON(dst []byte, v null.Time) ([]byte, error) {
+	if !v.Valid {
+		return append(dst, nullLiteral...), nil
+	}
+	t := v.Time.UTC()
+	if sec := t.Unix(); sec < minTimestampSeconds || sec > maxTimestampSeconds {
+		return dst, ErrTimestampRange
+	}
+	layout := "2006-01-02T15:04:05Z"
+	switch nsec := t.Nanosecond(); {
+	case nsec == 0:
+	case nsec%1e6 == 0:
+		layout = "2006-01-02T15:04:05.000Z"
+	case nsec%1e3 == 0:
+		layout = "2006-01-02T15:04:05.000000Z"
+	default:
+		layout = "2006-01-02T15:04:05.000000000Z"
+	}
+	dst = append(dst, '"')
+	dst = t.AppendFormat(dst, layout)
+	return append(dst, '"'), nil
+}
+
+// UnmarshalTimestampJSON, parses the protojson encoding of a
+// google.protobuf.Timestamp, a RFC 3339 string. The time is returned in
+// UTC.
+func UnmarshalTimestampJSON(data []byte) (null.Time, error) {
+	data = bytes.TrimSpace(data)
+	if isNull(data) {
+		return null.Time{}, nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return null.Time{}, errors.New("nullpb: invalid Timestamp: " + string(data))
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(data[1:len(data)-1]))
+	if err != nil {
+		return null.Time{}, err
+	}
+	if sec := t.Unix(); sec < minTimestampSeconds || sec > maxTimestampSeconds {
+		return null.Time{}, ErrTimestampRange
+	}
+	return null.NewTime(t.UTC()), nil
+}
@@ -0,0 +1,133 @@
+package nullpb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/charlievieth/null"
+)
+
+func TestInt64ValueJSON(t *testing.T) {
+	if b := AppendInt64ValueJSON(nil, null.NewInt(-42)); string(b) != `"-42"` {
+		t.Errorf("AppendInt64ValueJSON(-42) = %s, want %s", b, `"-42"`)
+	}
+	if b := AppendInt64ValueJSON(nil, null.Int{}); string(b) != "null" {
+		t.Errorf("AppendInt64ValueJSON(NULL) = %s, want null", b)
+	}
+	for _, in := range []string{`"-42"`, `-42`, ` -42 `} {
+		if v, err := UnmarshalInt64ValueJSON([]byte(in)); err != nil || v != null.NewInt(-42) {
+			t.Errorf("UnmarshalInt64ValueJSON(%s) = %+v, %v", in, v, err)
+		}
+	}
+	if v, err := UnmarshalInt64ValueJSON([]byte("null")); err != nil || v.Valid {
+		t.Errorf("UnmarshalInt64ValueJSON(null) = %+v, %v", v, err)
+	}
+}
+
+func TestDoubleValueJSON(t *testing.T) {
+	tests := []struct {
+		in  float64
+		out string
+	}{
+		{1.5, "1.5"},
+		{1e21, "1e+21"},
+		{math.NaN(), `"NaN"`},
+		{math.Inf(1), `"Infinity"`},
+		{math.Inf(-1), `"-Infinity"`},
+	}
+	for _, test := range tests {
+		b := AppendDoubleValueJSON(nil, null.NewFloat64(test.in))
+		if string(b) != test.out {
+			t.Errorf("AppendDoubleValueJSON(%v) = %s, want %s", test.in, b, test.out)
+		}
+		v, err := UnmarshalDoubleValueJSON(b)
+		if err != nil || !v.Valid || !(v.Float64 == test.in || math.IsNaN(test.in) && math.IsNaN(v.Float64)) {
+			t.Errorf("UnmarshalDoubleValueJSON(%s) = %+v, %v want %v", b, v, err, test.in)
+		}
+		b = AppendFloatValueJSON(nil, null.NewFloat32(float32(test.in)))
+		if string(b) != test.out {
+			t.Errorf("AppendFloatValueJSON(%v) = %s, want %s", test.in, b, test.out)
+		}
+		f, err := UnmarshalFloatValueJSON(b)
+		if err != nil || !f.Valid || !(f.Float32 == float32(test.in) || math.IsNaN(test.in) && f.Float32 != f.Float32) {
+			t.Errorf("UnmarshalFloatValueJSON(%s) = %+v, %v want %v", b, f, err, test.in)
+		}
+	}
+	if v, err := UnmarshalDoubleValueJSON([]byte(`"2.5"`)); err != nil || v != null.NewFloat64(2.5) {
+		t.Errorf("UnmarshalDoubleValueJSON(%s) = %+v, %v", `"2.5"`, v, err)
+	}
+	if v, err := UnmarshalDoubleValueJSON([]byte("null")); err != nil || v.Valid {
+		t.Errorf("UnmarshalDoubleValueJSON(null) = %+v, %v", v, err)
+	}
+	if _, err := UnmarshalDoubleValueJSON([]byte(`"x"`)); err == nil {
+		t.Errorf("UnmarshalDoubleValueJSON(%s): expected error", `"x"`)
+	}
+}
+
+func TestStringBoolValueJSON(t *testing.T) {
+	if b := AppendStringValueJSON(nil, null.NewString("a\"b")); string(b) != `"a\"b"` {
+		t.Errorf("AppendStringValueJSON = %s, want %s", b, `"a\"b"`)
+	}
+	if v, err := UnmarshalStringValueJSON([]byte(`"a\"b"`)); err != nil || v != null.NewString("a\"b") {
+		t.Errorf("UnmarshalStringValueJSON = %+v, %v", v, err)
+	}
+	if b := AppendBoolValueJSON(nil, null.NewBool(true)); string(b) != "true" {
+		t.Errorf("AppendBoolValueJSON(true) = %s, want true", b)
+	}
+	if v, err := UnmarshalBoolValueJSON([]byte("false")); err != nil || v != null.NewBool(false) {
+		t.Errorf("UnmarshalBoolValueJSON(false) = %+v, %v", v, err)
+	}
+	if _, err := UnmarshalBoolValueJSON([]byte(`"true"`)); err == nil {
+		t.Errorf("UnmarshalBoolValueJSON(%s): expected error", `"true"`)
+	}
+}
+
+func TestStringValueJSONEncoder(t *testing.T) {
+	defer func(e null.Encoder) { null.DefaultEncoder = e }(null.DefaultEncoder)
+	in := null.NewString("<a&b>\u2028")
+	want := "\"<a&b>\u2028\""
+	for _, e := range []null.Encoder{{}, {EscapeHTML: true, EscapeLineSeparators: true}} {
+		null.DefaultEncoder = e
+		if b := AppendStringValueJSON(nil, in); string(b) != want {
+			t.Errorf("AppendStringValueJSON(%+v) = %s, want %s", e, b, want)
+		}
+	}
+	if b := AppendStringValueJSON(nil, null.String{}); string(b) != "null" {
+		t.Errorf("AppendStringValueJSON(NULL) = %s, want null", b)
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	tests := []struct {
+		in  time.Time
+		out string
+	}{
+		{time.Date(1972, 1, 1, 10, 0, 20, 0, time.UTC), `"1972-01-01T10:00:20Z"`},
+		{time.Date(1972, 1, 1, 10, 0, 20, 20000000, time.UTC), `"1972-01-01T10:00:20.020Z"`},
+		{time.Date(1972, 1, 1, 10, 0, 20, 20000, time.UTC), `"1972-01-01T10:00:20.000020Z"`},
+		{time.Date(1972, 1, 1, 10, 0, 20, 1, time.UTC), `"1972-01-01T10:00:20.000000001Z"`},
+		{time.Date(1972, 1, 1, 11, 0, 20, 0, time.FixedZone("", 3600)), `"1972-01-01T10:00:20Z"`},
+	}
+	for _, test := range tests {
+		b, err := AppendTimestampJSON(nil, null.NewTime(test.in))
+		if err != nil || string(b) != test.out {
+			t.Errorf("AppendTimestampJSON(%v) = %s, %v want %s", test.in, b, err, test.out)
+		}
+		v, err := UnmarshalTimestampJSON(b)
+		if err != nil || !v.Time.Equal(test.in) || v.Time.Location() != time.UTC {
+			t.Errorf("UnmarshalTimestampJSON(%s) = %v, %v want %v", b, v.Time, err, test.in)
+		}
+	}
+	if v, err := UnmarshalTimestampJSON([]byte(`"1972-01-01T11:00:20+01:00"`)); err != nil || !v.Time.Equal(tests[0].in) {
+		t.Errorf("UnmarshalTimestampJSON(offset) = %v, %v", v.Time, err)
+	}
+	if _, err := AppendTimestampJSON(nil, null.NewTime(time.Time{}.Add(-time.Second))); err != ErrTimestampRange {
+		t.Errorf("AppendTimestampJSON(year 0): got error %v, want %v", err, ErrTimestampRange)
+	}
+	for _, in := range []string{`1`, `"1972-01-01"`, `"0000-12-31T23:59:59Z"`} {
+		if _, err := UnmarshalTimestampJSON([]byte(in)); err == nil {
+			t.Errorf("UnmarshalTimestampJSON(%s): expected error", in)
+		}
+	}
+}
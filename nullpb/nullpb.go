@@ -0,0 +1,287 @@
+// Package nullpb converts the types of package null to and from the
+// protobuf well-known wrapper messages, without depending on the protobuf
+// module.
+//
+//	null.Int      google.protobuf.Int64Value
+//	null.Float64  google.protobuf.DoubleValue
+//	null.Float32  google.protobuf.FloatValue
+//	null.String   google.protobuf.StringValue
+//	null.Bool     google.protobuf.BoolValue
+//	null.Time     google.protobuf.Timestamp
+//
+// The Append*Value functions append the wire format encoding of a wrapper
+// message and the Unmarshal*Value functions parse it. A present message is
+// always valid, a NULL value is represented by the absence of the message
+// field, so hand-written codecs should use the Append*Field functions to
+// embed a wrapper as a field of an enclosing message and leave the null type
+// NULL if the field is not present.
+//
+// The *JSON functions implement the protojson mapping of each wrapper,
+// where NULL is represented as JSON null.
+package nullpb
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/charlievieth/null"
+)
+
+// Range of google.protobuf.Timestamp, 0001-01-01T00:00:00Z to
+// 9999-12-31T23:59:59.999999999Z.
+const (
+	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = 253402300799
+)
+
+// ErrTimestampRange, is returned for times outside the range of
+// google.protobuf.Timestamp.
+var ErrTimestampRange = errors.New("nullpb: timestamp out of range")
+
+// AppendInt64Value, appends the google.protobuf.Int64Value message of Int v
+// to dst. NULL values append nothing.
+func AppendInt64Value(dst []byte, v null.Int) []byte {
+	if v.Valid && v.Int != 0 {
+		dst = appendTag(dst, 1, wireVarint)
+		dst = appendVarint(dst, uint64(int64(v.Int)))
+	}
+	return dst
+}
+
+// AppendInt64Field, appends field num of type google.protobuf.Int64Value
+// holding Int v to dst. NULL values are omitted.
+func AppendInt64Field(dst []byte, num int, v null.Int) []byte {
+	if !v.Valid {
+		return dst
+	}
+	return appendMessage(dst, num, AppendInt64Value(nil, v))
+}
+
+// UnmarshalInt64Value, parses the google.protobuf.Int64Value message b.
+func UnmarshalInt64Value(b []byte) (null.Int, error) {
+	var n int64
+	err := rangeFields(b, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+		n = int64(f.varint)
+		return checkType(f, wireVarint)
+	})
+	if err != nil {
+		return null.Int{}, err
+	}
+	if int64(int(n)) != n {
+		return null.Int{}, errors.New("nullpb: Int64Value overflows int")
+	}
+	return null.NewInt(int(n)), nil
+}
+
+// AppendDoubleValue, appends the google.protobuf.DoubleValue message of
+// Float64 v to dst. NULL values append nothing.
+func AppendDoubleValue(dst []byte, v null.Float64) []byte {
+	if v.Valid && math.Float64bits(v.Float64) != 0 {
+		dst = appendTag(dst, 1, wireFixed64)
+		dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(v.Float64))
+	}
+	return dst
+}
+
+// AppendDoubleField, appends field num of type google.protobuf.DoubleValue
+// holding Float64 v to dst. NULL values are omitted.
+func AppendDoubleField(dst []byte, num int, v null.Float64) []byte {
+	if !v.Valid {
+		return dst
+	}
+	return appendMessage(dst, num, AppendDoubleValue(nil, v))
+}
+
+// UnmarshalDoubleValue, parses the google.protobuf.DoubleValue message b.
+func UnmarshalDoubleValue(b []byte) (null.Float64, error) {
+	var bits uint64
+	err := rangeFields(b, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+		bits = f.varint
+		return checkType(f, wireFixed64)
+	})
+	if err != nil {
+		return null.Float64{}, err
+	}
+	return null.NewFloat64(math.Float64frombits(bits)), nil
+}
+
+// AppendFloatValue, appends the google.protobuf.FloatValue message of
+// Float32 v to dst. NULL values append nothing.
+func AppendFloatValue(dst []byte, v null.Float32) []byte {
+	if v.Valid && math.Float32bits(v.Float32) != 0 {
+		dst = appendTag(dst, 1, wireFixed32)
+		dst = binary.LittleEndian.AppendUint32(dst, math.Float32bits(v.Float32))
+	}
+	return dst
+}
+
+// AppendFloatField, appends field num of type google.protobuf.FloatValue
+// holding Float32 v to dst. NULL values are omitted.
+func AppendFloatField(dst []byte, num int, v null.Float32) []byte {
+	if !v.Valid {
+		return dst
+	}
+	return appendMessage(dst, num, AppendFloatValue(nil, v))
+}
+
+// UnmarshalFloatValue, parses the google.protobuf.FloatValue message b.
+func UnmarshalFloatValue(b []byte) (null.Float32, error) {
+	var bits uint32
+	err := rangeFields(b, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+		bits = uint32(f.varint)
+		return checkType(f, wireFixed32)
+	})
+	if err != nil {
+		return null.Float32{}, err
+	}
+	return null.NewFloat32(math.Float32frombits(bits)), nil
+}
+
+// AppendStringValue, appends the google.protobuf.StringValue message of
+// String v to dst. NULL values append nothing.
+func AppendStringValue(dst []byte, v null.String) []byte {
+	if v.Valid && v.String != "" {
+		dst = appendTag(dst, 1, wireBytes)
+		dst = appendVarint(dst, uint64(len(v.String)))
+		dst = append(dst, v.String...)
+	}
+	return dst
+}
+
+// AppendStringField, appends field num of type google.protobuf.StringValue
+// holding String v to dst. NULL values are omitted.
+func AppendStringField(dst []byte, num int, v null.String) []byte {
+	if !v.Valid {
+		return dst
+	}
+	n := len(v.String)
+	if n > 0 {
+		n += 1 + sizeVarint(uint64(n))
+	}
+	dst = appendTag(dst, num, wireBytes)
+	dst = appendVarint(dst, uint64(n))
+	return AppendStringValue(dst, v)
+}
+
+// UnmarshalStringValue, parses the google.protobuf.StringValue message b.
+// The string is not validated as UTF-8.
+func UnmarshalStringValue(b []byte) (null.String, error) {
+	var s []byte
+	err := rangeFields(b, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+		s = f.bytes
+		return checkType(f, wireBytes)
+	})
+	if err != nil {
+		return null.String{}, err
+	}
+	return null.NewString(string(s)), nil
+}
+
+// AppendBoolValue, appends the google.protobuf.BoolValue message of Bool v
+// to dst. NULL values append nothing.
+func AppendBoolValue(dst []byte, v null.Bool) []byte {
+	if v.Valid && v.Bool {
+		dst = appendTag(dst, 1, wireVarint)
+		dst = append(dst, 1)
+	}
+	return dst
+}
+
+// AppendBoolField, appends field num of type google.protobuf.BoolValue
+// holding Bool v to dst. NULL values are omitted.
+func AppendBoolField(dst []byte, num int, v null.Bool) []byte {
+	if !v.Valid {
+		return dst
+	}
+	return appendMessage(dst, num, AppendBoolValue(nil, v))
+}
+
+// UnmarshalBoolValue, parses the google.protobuf.BoolValue message b.
+func UnmarshalBoolValue(b []byte) (null.Bool, error) {
+	var v bool
+	err := rangeFields(b, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+		v = f.varint != 0
+		return checkType(f, wireVarint)
+	})
+	if err != nil {
+		return null.Bool{}, err
+	}
+	return null.NewBool(v), nil
+}
+
+// AppendTimestamp, appends the google.protobuf.Timestamp message of Time v
+// to dst. NULL values append nothing. ErrTimestampRange is returned if the
+// time is outside the range of Timestamp.
+func AppendTimestamp(dst []byte, v null.Time) ([]byte, error) {
+	if !v.Valid {
+		return dst, nil
+	}
+	sec, nsec := v.Time.Unix(), v.Time.Nanosecond()
+	if sec < minTimestampSeconds || sec > maxTimestampSeconds {
+		return dst, ErrTimestampRange
+	}
+	if sec != 0 {
+		dst = appendTag(dst, 1, wireVarint)
+		dst = appendVarint(dst, uint64(sec))
+	}
+	if nsec != 0 {
+		dst = appendTag(dst, 2, wireVarint)
+		dst = appendVarint(dst, uint64(nsec))
+	}
+	return dst, nil
+}
+
+// AppendTimestampField, appends field num of type google.protobuf.Timestamp
+// holding Time v to dst. NULL values are omitted.
+func AppendTimestampField(dst []byte, num int, v null.Time) ([]byte, error) {
+	if !v.Valid {
+		return dst, nil
+	}
+	var a [24]byte
+	msg, err := AppendTimestamp(a[:0], v)
+	if err != nil {
+		return dst, err
+	}
+	return appendMessage(dst, num, msg), nil
+}
+
+// UnmarshalTimestamp, parses the google.protobuf.Timestamp message b. The
+// time is returned in UTC.
+func UnmarshalTimestamp(b []byte) (null.Time, error) {
+	var sec, nsec int64
+	err := rangeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			sec = int64(f.varint)
+		case 2:
+			nsec = int64(int32(f.varint))
+		default:
+			return nil
+		}
+		return checkType(f, wireVarint)
+	})
+	if err != nil {
+		return null.Time{}, err
+	}
+	if sec < minTimestampSeconds || sec > maxTimestampSeconds || nsec < 0 || nsec >= 1e9 {
+		return null.Time{}, ErrTimestampRange
+	}
+	return null.NewTime(time.Unix(sec, nsec).UTC()), nil
+}
@@ -0,0 +1,177 @@
+package nullpb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charlievieth/null"
+)
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestInt64Value(t *testing.T) {
+	tests := []struct {
+		in  null.Int
+		out string
+	}{
+		{null.NewInt(0), ""},
+		{null.NewInt(150), "089601"},
+		{null.NewInt(-1), "08ffffffffffffffffff01"},
+	}
+	for _, test := range tests {
+		b := AppendInt64Value(nil, test.in)
+		if hex.EncodeToString(b) != test.out {
+			t.Errorf("AppendInt64Value(%+v) = %x, want %s", test.in, b, test.out)
+		}
+		v, err := UnmarshalInt64Value(b)
+		if err != nil || v != test.in {
+			t.Errorf("UnmarshalInt64Value(%x) = %+v, %v want %+v", b, v, err, test.in)
+		}
+	}
+	if b := AppendInt64Field(nil, 3, null.NewInt(150)); hex.EncodeToString(b) != "1a03089601" {
+		t.Errorf("AppendInt64Field(3, 150) = %x, want 1a03089601", b)
+	}
+	if b := AppendInt64Field(nil, 3, null.NewInt(0)); hex.EncodeToString(b) != "1a00" {
+		t.Errorf("AppendInt64Field(3, 0) = %x, want 1a00", b)
+	}
+	if b := AppendInt64Field(nil, 3, null.Int{}); len(b) != 0 {
+		t.Errorf("AppendInt64Field(3, NULL) = %x, want empty", b)
+	}
+	// Unknown fields are skipped and the last value wins.
+	if v, err := UnmarshalInt64Value(unhex("0801" + "120161" + "0802")); err != nil || v != null.NewInt(2) {
+		t.Errorf("UnmarshalInt64Value(repeated) = %+v, %v want 2", v, err)
+	}
+}
+
+func TestDoubleFloatValue(t *testing.T) {
+	b := AppendDoubleValue(nil, null.NewFloat64(1.5))
+	if hex.EncodeToString(b) != "09000000000000f83f" {
+		t.Errorf("AppendDoubleValue(1.5) = %x, want 09000000000000f83f", b)
+	}
+	if v, err := UnmarshalDoubleValue(b); err != nil || v != null.NewFloat64(1.5) {
+		t.Errorf("UnmarshalDoubleValue(%x) = %+v, %v", b, v, err)
+	}
+	if b := AppendDoubleValue(nil, null.NewFloat64(math.Copysign(0, -1))); len(b) == 0 {
+		t.Error("AppendDoubleValue(-0): expected -0 to be encoded")
+	}
+	b = AppendFloatValue(nil, null.NewFloat32(1.5))
+	if hex.EncodeToString(b) != "0d0000c03f" {
+		t.Errorf("AppendFloatValue(1.5) = %x, want 0d0000c03f", b)
+	}
+	if v, err := UnmarshalFloatValue(b); err != nil || v != null.NewFloat32(1.5) {
+		t.Errorf("UnmarshalFloatValue(%x) = %+v, %v", b, v, err)
+	}
+	if b := AppendDoubleField(nil, 1, null.NewFloat64(0)); hex.EncodeToString(b) != "0a00" {
+		t.Errorf("AppendDoubleField(1, 0) = %x, want 0a00", b)
+	}
+	if b := AppendFloatField(nil, 1, null.Float32{}); len(b) != 0 {
+		t.Errorf("AppendFloatField(1, NULL) = %x, want empty", b)
+	}
+}
+
+func TestStringValue(t *testing.T) {
+	b := AppendStringValue(nil, null.NewString("hi"))
+	if hex.EncodeToString(b) != "0a026869" {
+		t.Errorf("AppendStringValue(hi) = %x, want 0a026869", b)
+	}
+	if v, err := UnmarshalStringValue(b); err != nil || v != null.NewString("hi") {
+		t.Errorf("UnmarshalStringValue(%x) = %+v, %v", b, v, err)
+	}
+	for _, s := range []string{"", "hi", strings.Repeat("x", 200)} {
+		b := AppendStringField([]byte{0xff}, 2, null.NewString(s))
+		want := appendMessage([]byte{0xff}, 2, AppendStringValue(nil, null.NewString(s)))
+		if !bytes.Equal(b, want) {
+			t.Errorf("AppendStringField(2, %d bytes) = %x, want %x", len(s), b, want)
+		}
+	}
+	if b := AppendStringField(nil, 2, null.String{}); len(b) != 0 {
+		t.Errorf("AppendStringField(2, NULL) = %x, want empty", b)
+	}
+}
+
+func TestBoolValue(t *testing.T) {
+	for _, test := range []struct {
+		in  null.Bool
+		out string
+	}{
+		{null.NewBool(true), "0801"},
+		{null.NewBool(false), ""},
+	} {
+		b := AppendBoolValue(nil, test.in)
+		if hex.EncodeToString(b) != test.out {
+			t.Errorf("AppendBoolValue(%+v) = %x, want %s", test.in, b, test.out)
+		}
+		if v, err := UnmarshalBoolValue(b); err != nil || v != test.in {
+			t.Errorf("UnmarshalBoolValue(%x) = %+v, %v want %+v", b, v, err, test.in)
+		}
+	}
+	if b := AppendBoolField(nil, 4, null.NewBool(true)); hex.EncodeToString(b) != "22020801" {
+		t.Errorf("AppendBoolField(4, true) = %x, want 22020801", b)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		in  time.Time
+		out string
+	}{
+		{time.Unix(0, 0), ""},
+		{time.Unix(1, 5), "08011005"},
+		{time.Unix(-1, 0), "08ffffffffffffffffff01"},
+	}
+	for _, test := range tests {
+		b, err := AppendTimestamp(nil, null.NewTime(test.in))
+		if err != nil || hex.EncodeToString(b) != test.out {
+			t.Errorf("AppendTimestamp(%v) = %x, %v want %s", test.in, b, err, test.out)
+		}
+		v, err := UnmarshalTimestamp(b)
+		if err != nil || !v.Valid || !v.Time.Equal(test.in) {
+			t.Errorf("UnmarshalTimestamp(%x) = %+v, %v want %v", b, v, err, test.in)
+		}
+	}
+	b, err := AppendTimestampField(nil, 5, null.NewTime(time.Unix(1, 5)))
+	if err != nil || hex.EncodeToString(b) != "2a0408011005" {
+		t.Errorf("AppendTimestampField(5, 1.000000005) = %x, %v want 2a0408011005", b, err)
+	}
+	if b, err := AppendTimestampField(nil, 5, null.Time{}); len(b) != 0 || err != nil {
+		t.Errorf("AppendTimestampField(5, NULL) = %x, %v want empty", b, err)
+	}
+	if _, err := AppendTimestamp(nil, null.NewTime(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))); err != ErrTimestampRange {
+		t.Errorf("AppendTimestamp(year 10000): got error %v, want %v", err, ErrTimestampRange)
+	}
+	if _, err := UnmarshalTimestamp(unhex("108094ebdc03")); err != ErrTimestampRange {
+		t.Errorf("UnmarshalTimestamp(nanos 1e9): got error %v, want %v", err, ErrTimestampRange)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, s := range []string{
+		"08",                     // truncated varint
+		"0a05",                   // truncated bytes
+		"09000000",               // truncated fixed64
+		"0d00",                   // truncated fixed32
+		"0b",                     // group
+		"00",                     // field number 0
+		"ffffffffffffffffffff01", // overflow
+	} {
+		if _, err := UnmarshalInt64Value(unhex(s)); err == nil {
+			t.Errorf("UnmarshalInt64Value(%s): expected error", s)
+		}
+	}
+	if _, err := UnmarshalInt64Value(unhex("0d00000000")); err == nil {
+		t.Error("UnmarshalInt64Value(fixed32): expected wire type error")
+	}
+	if _, err := UnmarshalStringValue(unhex("0801")); err == nil {
+		t.Error("UnmarshalStringValue(varint): expected wire type error")
+	}
+}
@@ -0,0 +1,123 @@
+package nullpb
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var (
+	errTruncated = errors.New("nullpb: truncated message")
+	errOverflow  = errors.New("nullpb: varint overflows a 64-bit integer")
+	errWireType  = errors.New("nullpb: invalid wire type")
+	errFieldNum  = errors.New("nullpb: invalid field number")
+)
+
+// appendVarint, appends the base 128 varint encoding of v to dst.
+func appendVarint(dst []byte, v uint64) []byte {
+	return binary.AppendUvarint(dst, v)
+}
+
+// sizeVarint, returns the size of the varint encoding of v.
+func sizeVarint(v uint64) int {
+	return 1 + (bits.Len64(v|1)-1)/7
+}
+
+// appendTag, appends the key of field num with wire type typ to dst.
+func appendTag(dst []byte, num int, typ int) []byte {
+	return appendVarint(dst, uint64(num)<<3|uint64(typ))
+}
+
+// appendMessage, appends field num holding the embedded message msg.
+func appendMessage(dst []byte, num int, msg []byte) []byte {
+	dst = appendTag(dst, num, wireBytes)
+	dst = appendVarint(dst, uint64(len(msg)))
+	return append(dst, msg...)
+}
+
+// consumeVarint, parses a varint from b and returns it and its length.
+func consumeVarint(b []byte) (uint64, int, error) {
+	v, n := binary.Uvarint(b)
+	switch {
+	case n == 0:
+		return 0, 0, errTruncated
+	case n < 0:
+		return 0, 0, errOverflow
+	}
+	return v, n, nil
+}
+
+// A field is a field of a message parsed by rangeFields.
+type field struct {
+	num    int
+	typ    int
+	varint uint64 // value of varint, fixed32 and fixed64 fields
+	bytes  []byte // value of length-delimited fields
+}
+
+// rangeFields, calls fn for each field of message b in order.
+func rangeFields(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		key, n, err := consumeVarint(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		f := field{num: int(key >> 3), typ: int(key & 7)}
+		if key>>3 == 0 || key>>3 > 1<<29-1 {
+			return errFieldNum
+		}
+		switch f.typ {
+		case wireVarint:
+			f.varint, n, err = consumeVarint(b)
+			if err != nil {
+				return err
+			}
+		case wireFixed64:
+			if len(b) < 8 {
+				return errTruncated
+			}
+			f.varint, n = binary.LittleEndian.Uint64(b), 8
+		case wireFixed32:
+			if len(b) < 4 {
+				return errTruncated
+			}
+			f.varint, n = uint64(binary.LittleEndian.Uint32(b)), 4
+		case wireBytes:
+			var size uint64
+			size, n, err = consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			if size > uint64(len(b)-n) {
+				return errTruncated
+			}
+			f.bytes = b[n : n+int(size)]
+			n += int(size)
+		default:
+			// Groups are deprecated and never used by the wrapper types.
+			return errWireType
+		}
+		b = b[n:]
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkType, returns an error if field f is not of wire type typ.
+func checkType(f field, typ int) error {
+	if f.typ != typ {
+		return errWireType
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package null
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/charlievieth/null/pgcopy"
+)
+
+// AppendCopyText, appends Int i to dst in the text format of the Postgres
+// COPY command. NULL is appended as \N.
+func (i Int) AppendCopyText(dst []byte) ([]byte, error) {
+	if i.Valid {
+		return strconv.AppendInt(dst, int64(i.Int), 10), nil
+	}
+	return pgcopy.AppendTextNull(dst), nil
+}
+
+// AppendCopyBinary, appends Int i to dst as a bigint field in the binary
+// format of the Postgres COPY command. NULL is appended as a length of -1.
+func (i Int) AppendCopyBinary(dst []byte) ([]byte, error) {
+	if i.Valid {
+		return pgcopy.AppendBinaryInt8(dst, int64(i.Int)), nil
+	}
+	return pgcopy.AppendBinaryNull(dst), nil
+}
+
+// AppendCopyText, appends Uint64 u to dst in the text format of the Postgres
+// COPY command. NULL is appended as \N.
+func (u Uint64) AppendCopyText(dst []byte) ([]byte, error) {
+	if u.Valid {
+		return strconv.AppendUint(dst, u.Uint64, 10), nil
+	}
+	return pgcopy.AppendTextNull(dst), nil
+}
+
+// AppendCopyBinary, appends Uint64 u to dst as a bigint field in the binary
+// format of the Postgres COPY command. NULL is appended as a length of -1.
+// Postgres has no unsigned types, values greater than math.MaxInt64 return
+// an error wrapping ErrRange.
+func (u Uint64) AppendCopyBinary(dst []byte) ([]byte, error) {
+	if !u.Valid {
+		return pgcopy.AppendBinaryNull(dst), nil
+	}
+	if u.Uint64 > math.MaxInt64 {
+		return dst, fmt.Errorf("null: Uint64 %d out of range for bigint: %w", u.Uint64, ErrRange)
+	}
+	return pgcopy.AppendBinaryInt8(dst, int64(u.Uint64)), nil
+}
+
+// AppendCopyText, appends Float64 f to dst in the text format of the
+// Postgres COPY command. NULL is appended as \N.
+func (f Float64) AppendCopyText(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return pgcopy.AppendTextFloat(dst, f.Float64, 64), nil
+	}
+	return pgcopy.AppendTextNull(dst), nil
+}
+
+// AppendCopyBinary, appends Float64 f to dst as a double precision field in
+// the binary format of the Postgres COPY command. NULL is appended as a
+// length of -1.
+func (f Float64) AppendCopyBinary(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return pgcopy.AppendBinaryFloat8(dst, f.Float64), nil
+	}
+	return pgcopy.AppendBinaryNull(dst), nil
+}
+
+// AppendCopyText, appends Float32 f to dst in the text format of the
+// Postgres COPY command. NULL is appended as \N.
+func (f Float32) AppendCopyText(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return pgcopy.AppendTextFloat(dst, float64(f.Float32), 32), nil
+	}
+	return pgcopy.AppendTextNull(dst), nil
+}
+
+// AppendCopyBinary, appends Float32 f to dst as a real field in the binary
+// format of the Postgres COPY command. NULL is appended as a length of -1.
+func (f Float32) AppendCopyBinary(dst []byte) ([]byte, error) {
+	if f.Valid {
+		return pgcopy.AppendBinaryFloat4(dst, f.Float32), nil
+	}
+	return pgcopy.AppendBinaryNull(dst), nil
+}
+
+// AppendCopyText, appends String s to dst in the text format of the Postgres
+// COPY command, escaping backslashes and control characters. NULL is
+// appended as \N.
+func (s String) AppendCopyText(dst []byte) ([]byte, error) {
+	if s.Valid {
+		return pgcopy.AppendTextString(dst, s.String), nil
+	}
+	return pgcopy.AppendTextNull(dst), nil
+}
+
+// AppendCopyBinary, appends String s to dst as a text field in the binary
+// format of the Postgres COPY command. NULL is appended as a length of -1.
+func (s String) AppendCopyBinary(dst []byte) ([]byte, error) {
+	if s.Valid {
+		return pgcopy.AppendBinaryString(dst, s.String), nil
+	}
+	return pgcopy.AppendBinaryNull(dst), nil
+}
+
+// AppendCopyText, appends UnescapedString s to dst, see String.
+func (s UnescapedString) AppendCopyText(dst []byte) ([]byte, error) {
+	return String(s).AppendCopyText(dst)
+}
+
+// AppendCopyBinary, appends UnescapedString s to dst, see String.
+func (s UnescapedString) AppendCopyBinary(dst []byte) ([]byte, error) {
+	return String(s).AppendCopyBinary(dst)
+}
+
+// AppendCopyText, appends Bool b to dst as t or f in the text format of the
+// Postgres COPY command. NULL is appended as \N.
+func (b Bool) AppendCopyText(dst []byte) ([]byte, error) {
+	if b.Valid {
+		return pgcopy.AppendTextBool(dst, b.Bool), nil
+	}
+	return pgcopy.AppendTextNull(dst), nil
+}
+
+// AppendCopyBinary, appends Bool b to dst as a boolean field in the binary
+// format of the Postgres COPY command. NULL is appended as a length of -1.
+func (b Bool) AppendCopyBinary(dst []byte) ([]byte, error) {
+	if b.Valid {
+		return pgcopy.AppendBinaryBool(dst, b.Bool), nil
+	}
+	return pgcopy.AppendBinaryNull(dst), nil
+}
+
+// AppendCopyText, appends Time t to dst as a timestamptz literal in the text
+// format of the Postgres COPY command. The time is rounded to the
+// microsecond and converted to UTC. NULL is appended as \N. Years outside
+// 1 to 9999 return an error wrapping ErrRange.
+func (t Time) AppendCopyText(dst []byte) ([]byte, error) {
+	if t.Valid {
+		return pgcopy.AppendTextTime(dst, t.Time)
+	}
+	return pgcopy.AppendTextNull(dst), nil
+}
+
+// AppendCopyBinary, appends Time t to dst as a timestamptz field, the
+// microseconds since 2000-01-01 00:00:00 UTC, in the binary format of the
+// Postgres COPY command. NULL is appended as a length of -1.
+func (t Time) AppendCopyBinary(dst []byte) ([]byte, error) {
+	if t.Valid {
+		return pgcopy.AppendBinaryTime(dst, t.Time)
+	}
+	return pgcopy.AppendBinaryNull(dst), nil
+}
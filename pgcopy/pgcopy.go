@@ -0,0 +1,173 @@
+// Package pgcopy implements encoders for the text and binary formats of the
+// Postgres COPY FROM STDIN command, for bulk loading the value types of
+// package null.
+//
+// Values are appended to byte slices. In the text format every row is a line
+// of fields separated by tabs. In the binary format the stream starts with
+// AppendBinaryHeader, every row starts with AppendBinaryTuple followed by its
+// fields and the stream ends with AppendBinaryTrailer.
+//
+// See https://www.postgresql.org/docs/current/sql-copy.html for the formats.
+package pgcopy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// TextNull, is the text format representation of NULL.
+const TextNull = `\N`
+
+// BinarySignature, is the signature at the start of the binary format.
+const BinarySignature = "PGCOPY\n\xff\r\n\x00"
+
+// TimeLayout, is the layout of timestamps in the text format. Times are
+// formatted in UTC, so the offset is always +00:00.
+const TimeLayout = "2006-01-02 15:04:05.999999-07:00"
+
+// epoch2000, is the Postgres epoch, 2000-01-01 00:00:00 UTC, in Unix seconds.
+const epoch2000 = 946684800
+
+// AppendTextNull, appends a text format NULL to dst.
+func AppendTextNull(dst []byte) []byte {
+	return append(dst, TextNull...)
+}
+
+// AppendTextString, appends the string s to dst. Backslashes and the
+// control characters \b, \f, \n, \r, \t and \v are escaped with a backslash,
+// like COPY TO writes them.
+func AppendTextString(dst []byte, s string) []byte {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		var esc byte
+		switch s[i] {
+		case '\\':
+			esc = '\\'
+		case '\b':
+			esc = 'b'
+		case '\f':
+			esc = 'f'
+		case '\n':
+			esc = 'n'
+		case '\r':
+			esc = 'r'
+		case '\t':
+			esc = 't'
+		case '\v':
+			esc = 'v'
+		default:
+			continue
+		}
+		dst = append(dst, s[start:i]...)
+		dst = append(dst, '\\', esc)
+		start = i + 1
+	}
+	return append(dst, s[start:]...)
+}
+
+// AppendTextFloat, appends the float f of the given bitSize to dst. NaN and
+// infinities are appended as NaN, Infinity and -Infinity.
+func AppendTextFloat(dst []byte, f float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(dst, "NaN"...)
+	case math.IsInf(f, 1):
+		return append(dst, "Infinity"...)
+	case math.IsInf(f, -1):
+		return append(dst, "-Infinity"...)
+	}
+	return strconv.AppendFloat(dst, f, 'g', -1, bitSize)
+}
+
+// AppendTextBool, appends the bool v to dst as t or f.
+func AppendTextBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, 't')
+	}
+	return append(dst, 'f')
+}
+
+// AppendTextTime, appends the time v, rounded to the microsecond and
+// converted to UTC, to dst in TimeLayout, a timestamptz literal. UTC keeps
+// the instant of times whose offset has seconds, which TimeLayout can not
+// represent. Years before 1, which Postgres writes with BC, and after 9999
+// return an error wrapping strconv.ErrRange.
+func AppendTextTime(dst []byte, v time.Time) ([]byte, error) {
+	v = v.Round(time.Microsecond).UTC()
+	if y := v.Year(); y < 1 || y > 9999 {
+		return dst, fmt.Errorf("pgcopy: year of time %s out of range: %w", v, strconv.ErrRange)
+	}
+	return v.AppendFormat(dst, TimeLayout), nil
+}
+
+// AppendBinaryHeader, appends the header of the binary format, without
+// flags or header extension, to dst.
+func AppendBinaryHeader(dst []byte) []byte {
+	dst = append(dst, BinarySignature...)
+	dst = binary.BigEndian.AppendUint32(dst, 0)  // flags
+	return binary.BigEndian.AppendUint32(dst, 0) // header extension length
+}
+
+// AppendBinaryTrailer, appends the trailer of the binary format to dst.
+func AppendBinaryTrailer(dst []byte) []byte {
+	return binary.BigEndian.AppendUint16(dst, 0xffff)
+}
+
+// AppendBinaryTuple, appends the start of a row of n fields to dst.
+func AppendBinaryTuple(dst []byte, n int) []byte {
+	return binary.BigEndian.AppendUint16(dst, uint16(n))
+}
+
+// AppendBinaryNull, appends a NULL field, a length of -1, to dst.
+func AppendBinaryNull(dst []byte) []byte {
+	return binary.BigEndian.AppendUint32(dst, 0xffffffff)
+}
+
+// AppendBinaryInt8, appends a bigint field to dst.
+func AppendBinaryInt8(dst []byte, v int64) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, 8)
+	return binary.BigEndian.AppendUint64(dst, uint64(v))
+}
+
+// AppendBinaryFloat8, appends a double precision field to dst.
+func AppendBinaryFloat8(dst []byte, v float64) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, 8)
+	return binary.BigEndian.AppendUint64(dst, math.Float64bits(v))
+}
+
+// AppendBinaryFloat4, appends a real field to dst.
+func AppendBinaryFloat4(dst []byte, v float32) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, 4)
+	return binary.BigEndian.AppendUint32(dst, math.Float32bits(v))
+}
+
+// AppendBinaryString, appends a text field to dst.
+func AppendBinaryString(dst []byte, s string) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(s)))
+	return append(dst, s...)
+}
+
+// AppendBinaryBool, appends a boolean field to dst.
+func AppendBinaryBool(dst []byte, v bool) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, 1)
+	if v {
+		return append(dst, 1)
+	}
+	return append(dst, 0)
+}
+
+// AppendBinaryTime, appends a timestamptz field, the microseconds since
+// 2000-01-01 00:00:00 UTC, to dst. The time is rounded to the microsecond.
+// Like AppendTextTime, years before 1 and after 9999 return an error wrapping
+// strconv.ErrRange, which also keeps the microseconds from overflowing.
+func AppendBinaryTime(dst []byte, v time.Time) ([]byte, error) {
+	v = v.Round(time.Microsecond).UTC()
+	if y := v.Year(); y < 1 || y > 9999 {
+		return dst, fmt.Errorf("pgcopy: year of time %s out of range: %w", v, strconv.ErrRange)
+	}
+	us := (v.Unix()-epoch2000)*1e6 + int64(v.Nanosecond()/1e3)
+	return AppendBinaryInt8(dst, us), nil
+}
@@ -0,0 +1,139 @@
+package pgcopy
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAppendTextString(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{`a\b`, `a\\b`},
+		{"a\tb\nc\rd", `a\tb\nc\rd`},
+		{"\b\f\v", `\b\f\v`},
+		{`\N`, `\\N`},
+		{"héllo\x00", "héllo\x00"},
+	}
+	for _, test := range tests {
+		if got := string(AppendTextString(nil, test.in)); got != test.out {
+			t.Errorf("AppendTextString(%q) = %q want %q", test.in, got, test.out)
+		}
+	}
+}
+
+func TestAppendTextFloat(t *testing.T) {
+	tests := []struct {
+		in      float64
+		bitSize int
+		out     string
+	}{
+		{1.5, 64, "1.5"},
+		{0.1, 64, "0.1"},
+		{float64(float32(0.1)), 32, "0.1"},
+		{1e21, 64, "1e+21"},
+		{math.NaN(), 64, "NaN"},
+		{math.Inf(1), 64, "Infinity"},
+		{math.Inf(-1), 32, "-Infinity"},
+	}
+	for _, test := range tests {
+		if got := string(AppendTextFloat(nil, test.in, test.bitSize)); got != test.out {
+			t.Errorf("AppendTextFloat(%v, %d) = %q want %q", test.in, test.bitSize, got, test.out)
+		}
+	}
+}
+
+func TestAppendTextTime(t *testing.T) {
+	tests := []struct {
+		in  time.Time
+		out string
+	}{
+		{time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), "2006-01-02 15:04:05+00:00"},
+		{time.Date(2006, 1, 2, 15, 4, 5, 123456500, time.UTC), "2006-01-02 15:04:05.123457+00:00"},
+		{time.Date(2006, 1, 2, 15, 4, 5, 999999900, time.FixedZone("", -7*3600)), "2006-01-02 22:04:06+00:00"},
+		{time.Date(1900, 1, 1, 0, 0, 0, 0, time.FixedZone("LMT", 3600+2*60+5)), "1899-12-31 22:57:55+00:00"},
+		{time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), "0001-01-01 00:00:00+00:00"},
+		{time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC), "9999-12-31 23:59:59.999999+00:00"},
+	}
+	for _, test := range tests {
+		got, err := AppendTextTime(nil, test.in)
+		if err != nil || string(got) != test.out {
+			t.Errorf("AppendTextTime(%v) = %q, %v want %q", test.in, got, err, test.out)
+		}
+	}
+	for _, in := range []time.Time{
+		time.Date(0, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(1, 1, 1, 0, 30, 0, 0, time.FixedZone("", 3600)),
+		time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, 12, 31, 23, 59, 59, 999999500, time.UTC),
+	} {
+		if got, err := AppendTextTime([]byte("x"), in); !errors.Is(err, strconv.ErrRange) || string(got) != "x" {
+			t.Errorf("AppendTextTime(%v) = %q, %v want %v", in, got, err, strconv.ErrRange)
+		}
+	}
+	if got := string(AppendTextBool(AppendTextBool(nil, true), false)); got != "tf" {
+		t.Errorf("AppendTextBool = %q want %q", got, "tf")
+	}
+	if got := string(AppendTextNull(nil)); got != `\N` {
+		t.Errorf("AppendTextNull = %q want %q", got, `\N`)
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want []byte
+	}{
+		{"header", AppendBinaryHeader(nil), []byte("PGCOPY\n\xff\r\n\x00\x00\x00\x00\x00\x00\x00\x00\x00")},
+		{"trailer", AppendBinaryTrailer(nil), []byte{0xff, 0xff}},
+		{"tuple", AppendBinaryTuple(nil, 3), []byte{0, 3}},
+		{"null", AppendBinaryNull(nil), []byte{0xff, 0xff, 0xff, 0xff}},
+		{"int8", AppendBinaryInt8(nil, -2), []byte{0, 0, 0, 8, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}},
+		{"float8", AppendBinaryFloat8(nil, 1.5), []byte{0, 0, 0, 8, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"float4", AppendBinaryFloat4(nil, -2), []byte{0, 0, 0, 4, 0xc0, 0, 0, 0}},
+		{"string", AppendBinaryString(nil, "ab"), []byte{0, 0, 0, 2, 'a', 'b'}},
+		{"empty string", AppendBinaryString(nil, ""), []byte{0, 0, 0, 0}},
+		{"true", AppendBinaryBool(nil, true), []byte{0, 0, 0, 1, 1}},
+		{"false", AppendBinaryBool(nil, false), []byte{0, 0, 0, 1, 0}},
+	}
+	for _, test := range tests {
+		if !bytes.Equal(test.got, test.want) {
+			t.Errorf("%s: got % x want % x", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestAppendBinaryTime(t *testing.T) {
+	tests := []struct {
+		in  time.Time
+		out []byte
+	}{
+		{time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{time.Date(2000, 1, 1, 1, 0, 0, 1500, time.FixedZone("", 3600)), []byte{0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 2}},
+		{time.Date(1999, 12, 31, 23, 59, 59, 999999000, time.UTC), []byte{0, 0, 0, 8, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{time.Unix(0, 0), []byte{0, 0, 0, 8, 0xff, 0xfc, 0xa2, 0xfe, 0xc4, 0xc8, 0x20, 0x00}},
+	}
+	for _, test := range tests {
+		got, err := AppendBinaryTime(nil, test.in)
+		if err != nil || !bytes.Equal(got, test.out) {
+			t.Errorf("AppendBinaryTime(%v) = % x, %v want % x", test.in, got, err, test.out)
+		}
+	}
+	for _, in := range []time.Time{
+		time.Date(0, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(300000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(-300000, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if got, err := AppendBinaryTime([]byte("x"), in); !errors.Is(err, strconv.ErrRange) || string(got) != "x" {
+			t.Errorf("AppendBinaryTime(%v) = % x, %v want %v", in, got, err, strconv.ErrRange)
+		}
+	}
+}
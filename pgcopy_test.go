@@ -0,0 +1,106 @@
+package null
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/charlievieth/null/pgcopy"
+)
+
+type copyAppender interface {
+	AppendCopyText(dst []byte) ([]byte, error)
+	AppendCopyBinary(dst []byte) ([]byte, error)
+}
+
+func TestAppendCopyText(t *testing.T) {
+	at := time.Date(2006, 1, 2, 15, 4, 5, 123456000, time.FixedZone("", 3600))
+	rows := [][]copyAppender{
+		{
+			NewInt(-1), NewUint64(math.MaxUint64), NewFloat64(0.1), NewFloat32(0.1),
+			NewString("a\tb\\c\nd"), NewUnescapedString(""), NewBool(true), NewTime(at),
+		},
+		{Int{}, Uint64{}, Float64{}, Float32{}, String{}, UnescapedString{}, Bool{}, Time{}},
+	}
+	var buf []byte
+	for _, row := range rows {
+		for i, v := range row {
+			if i > 0 {
+				buf = append(buf, '\t')
+			}
+			var err error
+			if buf, err = v.AppendCopyText(buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		buf = append(buf, '\n')
+	}
+	want := "-1\t18446744073709551615\t0.1\t0.1\ta\\tb\\\\c\\nd\t\tt\t2006-01-02 14:04:05.123456+00:00\n" +
+		"\\N\t\\N\t\\N\t\\N\t\\N\t\\N\t\\N\t\\N\n"
+	if string(buf) != want {
+		t.Errorf("AppendCopyText:\ngot:  %q\nwant: %q", buf, want)
+	}
+}
+
+func TestAppendCopyTimeRange(t *testing.T) {
+	in := NewTime(time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC))
+	if b, err := in.AppendCopyText([]byte{1}); !errors.Is(err, ErrRange) || !bytes.Equal(b, []byte{1}) {
+		t.Errorf("AppendCopyText(%v) = %q, %v want %v", in.Time, b, err, ErrRange)
+	}
+	if b, err := in.AppendCopyBinary([]byte{1}); !errors.Is(err, ErrRange) || !bytes.Equal(b, []byte{1}) {
+		t.Errorf("AppendCopyBinary(%v) = %q, %v want %v", in.Time, b, err, ErrRange)
+	}
+}
+
+func TestAppendCopyBinary(t *testing.T) {
+	rows := [][]copyAppender{
+		{NewInt(1), NewFloat64(-2), NewFloat32(1), NewString("hi"), NewBool(false), NewTime(time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC))},
+		{Int{}, Float64{}, Float32{}, String{}, Bool{}, Time{}},
+	}
+	buf := pgcopy.AppendBinaryHeader(nil)
+	for _, row := range rows {
+		buf = pgcopy.AppendBinaryTuple(buf, len(row))
+		for _, v := range row {
+			var err error
+			if buf, err = v.AppendCopyBinary(buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	buf = pgcopy.AppendBinaryTrailer(buf)
+
+	want := []byte("PGCOPY\n\xff\r\n\x00")
+	want = append(want, 0, 0, 0, 0, 0, 0, 0, 0)
+	want = append(want, 0, 6)
+	want = append(want, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1)
+	want = append(want, 0, 0, 0, 8, 0xc0, 0, 0, 0, 0, 0, 0, 0)
+	want = append(want, 0, 0, 0, 4, 0x3f, 0x80, 0, 0)
+	want = append(want, 0, 0, 0, 2, 'h', 'i')
+	want = append(want, 0, 0, 0, 1, 0)
+	want = append(want, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0x0f, 0x42, 0x40)
+	want = append(want, 0, 6)
+	for i := 0; i < 6; i++ {
+		want = append(want, 0xff, 0xff, 0xff, 0xff)
+	}
+	want = append(want, 0xff, 0xff)
+	if !bytes.Equal(buf, want) {
+		t.Errorf("AppendCopyBinary:\ngot:  % x\nwant: % x", buf, want)
+	}
+}
+
+func TestAppendCopyBinaryUint64(t *testing.T) {
+	b, err := NewUint64(math.MaxInt64).AppendCopyBinary(nil)
+	want := []byte{0, 0, 0, 8, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	if err != nil || !bytes.Equal(b, want) {
+		t.Errorf("AppendCopyBinary = % x, %v want % x", b, err, want)
+	}
+	b, err = NewUint64(math.MaxInt64 + 1).AppendCopyBinary([]byte{1})
+	if !errors.Is(err, ErrRange) || !bytes.Equal(b, []byte{1}) {
+		t.Errorf("AppendCopyBinary = % x, %v want %v", b, err, ErrRange)
+	}
+	if b, _ := (Uint64{}).AppendCopyBinary(nil); !bytes.Equal(b, []byte{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("AppendCopyBinary(NULL) = % x", b)
+	}
+}
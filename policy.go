@@ -0,0 +1,228 @@
+package null
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Errors reported by a TimeError.
+var (
+	// ErrZeroDate, is the error for the MySQL zero date "0000-00-00".
+	ErrZeroDate = errors.New("null: zero date")
+
+	// ErrInvalidDate, is the error for dates with a zero month or day, e.g.
+	// "2024-00-10", or with a day that is out of range for the month, e.g.
+	// "2024-02-30", both of which MySQL accepts in lax SQL modes.
+	ErrInvalidDate = errors.New("null: invalid date")
+
+	// ErrYearRange, is the error for times with a year outside of the range
+	// [0,9999] representable by RFC 3339.
+	ErrYearRange = errors.New("null: year outside of range [0,9999]")
+)
+
+// A TimeError records a time that could not be represented by a Time.
+type TimeError struct {
+	Value string // the time
+	Err   error  // ErrZeroDate, ErrInvalidDate or ErrYearRange
+}
+
+func (e *TimeError) Error() string {
+	return e.Err.Error() + ": " + strconv.Quote(e.Value)
+}
+
+// Unwrap, returns the underlying error of TimeError e.
+func (e *TimeError) Unwrap() error { return e.Err }
+
+// An InvalidTimeAction is the action taken for a time that cannot be
+// represented by a Time.
+type InvalidTimeAction int
+
+const (
+	// InvalidTimeDefault, preserves the zero date as the zero time.Time
+	// and returns an error for all other invalid times.
+	InvalidTimeDefault InvalidTimeAction = iota
+
+	// InvalidTimeError, returns a *TimeError.
+	InvalidTimeError
+
+	// InvalidTimeNull, treats the time as NULL.
+	InvalidTimeNull
+
+	// InvalidTimeZero, preserves the time as a valid zero time.Time.
+	InvalidTimeZero
+)
+
+// A TimePolicy controls how a Time is normalized when it is scanned from, or
+// passed to, a database.
+//
+// The zero value of TimePolicy leaves times unchanged.
+type TimePolicy struct {
+	// Location, if not nil, is the location times are converted to, e.g.
+	// time.UTC. The instant in time is unchanged.
+	Location *time.Location
+
+	// Truncate, if greater than zero, is the precision times are truncated
+	// to, e.g. time.Millisecond for a DATETIME(3) column or time.Microsecond
+	// for a DATETIME(6) column. The database would otherwise round or
+	// truncate the time depending on its configuration.
+	Truncate time.Duration
+
+	// StripMonotonic, strips the monotonic clock reading of times, which
+	// has no meaning outside of the current process and causes times that
+	// are otherwise equal to compare unequal with ==. Truncating a time
+	// always strips its monotonic clock reading.
+	StripMonotonic bool
+
+	// ZeroDate, is the action taken when scanning the MySQL zero date
+	// "0000-00-00" or "0000-00-00 00:00:00". Since the MySQL driver returns
+	// the zero time.Time for zero dates when parseTime is enabled, the zero
+	// time.Time is treated as a zero date.
+	ZeroDate InvalidTimeAction
+
+	// InvalidDate, is the action taken when scanning a date with a zero
+	// month or day, or a day out of range for the month.
+	InvalidDate InvalidTimeAction
+
+	// StringLayout, is the layout used to format a time.Time scanned into
+	// a String, after it is normalized. If empty, time.RFC3339Nano is used,
+	// matching database/sql.
+	StringLayout string
+}
+
+// DefaultTimePolicy is the TimePolicy used by Time.Scan and Time.Value, and by
+// String.Scan for time.Time values.
+//
+// DefaultTimePolicy is not safe to modify while values are being scanned and
+// should only be changed during program initialization.
+var DefaultTimePolicy TimePolicy
+
+// Normalize, returns time t normalized by TimePolicy p.
+func (p *TimePolicy) Normalize(t time.Time) time.Time {
+	if p.Truncate > 0 {
+		t = t.Truncate(p.Truncate)
+	} else if p.StripMonotonic {
+		t = t.Round(0)
+	}
+	if p.Location != nil {
+		t = t.In(p.Location)
+	}
+	return t
+}
+
+// ScanTime, scans a database value into Time t and normalizes the time
+// with TimePolicy p.
+func (p *TimePolicy) ScanTime(value interface{}, t *Time) error {
+	var err error
+	switch v := value.(type) {
+	case nil:
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		t.Time = v
+		if v.IsZero() {
+			err = &TimeError{Value: "0000-00-00", Err: ErrZeroDate}
+		}
+	case []byte:
+		t.Time, err = parseDateTime(string(v))
+	case string:
+		t.Time, err = parseDateTime(v)
+	default:
+		t.Time, t.Valid = time.Time{}, false
+		return newScanError("null.Time", value, ErrUnsupportedType)
+	}
+	if err != nil {
+		var action InvalidTimeAction
+		var cause error
+		if terr, ok := err.(*TimeError); ok {
+			cause = terr.Err
+		}
+		switch cause {
+		case ErrZeroDate:
+			action = p.ZeroDate
+			if action == InvalidTimeDefault {
+				action = InvalidTimeZero
+			}
+		case ErrInvalidDate:
+			action = p.InvalidDate
+		}
+		switch action {
+		case InvalidTimeNull:
+			t.Time, t.Valid = time.Time{}, false
+			return nil
+		case InvalidTimeZero:
+			t.Time, t.Valid = time.Time{}, true
+			return nil
+		}
+		t.Time, t.Valid = time.Time{}, false
+		return newScanError("null.Time", value, err)
+	}
+	t.Time, t.Valid = p.Normalize(t.Time), true
+	return nil
+}
+
+// TimeValue, returns the database driver value of Time t normalized with
+// TimePolicy p.
+func (p *TimePolicy) TimeValue(t Time) (driver.Value, error) {
+	if t.Valid {
+		return p.Normalize(t.Time), nil
+	}
+	return nil, nil
+}
+
+// parseDateTime, parses a MySQL DATE or DATETIME string, in the format
+// "YYYY-MM-DD HH:MM:SS.ffffff" with optional time and fractional seconds,
+// as a time in UTC. Zero and invalid dates are reported as a *TimeError and
+// malformed strings as ErrSyntax.
+func parseDateTime(s string) (time.Time, error) {
+	var hour, min, sec, nsec int
+	ok := len(s) == 10 || len(s) == 19 || (len(s) >= 21 && len(s) <= 26)
+	ok = ok && s[4] == '-' && s[7] == '-'
+	year, ok := atoiDigits(s, 0, 4, ok)
+	month, ok := atoiDigits(s, 5, 7, ok)
+	day, ok := atoiDigits(s, 8, 10, ok)
+	if ok && len(s) > 10 {
+		ok = s[10] == ' ' && s[13] == ':' && s[16] == ':'
+		hour, ok = atoiDigits(s, 11, 13, ok)
+		min, ok = atoiDigits(s, 14, 16, ok)
+		sec, ok = atoiDigits(s, 17, 19, ok)
+		if ok && len(s) > 19 {
+			ok = s[19] == '.'
+			nsec, ok = atoiDigits(s, 20, len(s), ok)
+			nsec *= int(pow10tab[9-(len(s)-20)])
+		}
+	}
+	if !ok || hour > 23 || min > 59 || sec > 59 {
+		return time.Time{}, ErrSyntax
+	}
+	if year == 0 && month == 0 && day == 0 {
+		return time.Time{}, &TimeError{Value: s, Err: ErrZeroDate}
+	}
+	if month < 1 || month > 12 || day < 1 || day > daysIn(time.Month(month), year) {
+		return time.Time{}, &TimeError{Value: s, Err: ErrInvalidDate}
+	}
+	return time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC), nil
+}
+
+// atoiDigits, parses the decimal digits s[start:end], ok is false if any
+// are not digits or if ok is already false.
+func atoiDigits(s string, start, end int, ok bool) (int, bool) {
+	if !ok {
+		return 0, false
+	}
+	n := 0
+	for i := start; i < end; i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// daysIn, returns the number of days in month of year.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
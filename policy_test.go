@@ -0,0 +1,173 @@
+package null
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimePolicyNormalize(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	in := time.Date(2017, 10, 20, 23, 4, 56, 123456789, est)
+	tests := []struct {
+		policy TimePolicy
+		out    time.Time
+	}{
+		{TimePolicy{}, in},
+		{TimePolicy{StripMonotonic: true}, in},
+		{TimePolicy{Location: time.UTC}, time.Date(2017, 10, 21, 4, 4, 56, 123456789, time.UTC)},
+		{TimePolicy{Truncate: time.Millisecond}, time.Date(2017, 10, 20, 23, 4, 56, 123000000, est)},
+		{TimePolicy{Truncate: time.Microsecond}, time.Date(2017, 10, 20, 23, 4, 56, 123456000, est)},
+		{
+			TimePolicy{Location: time.UTC, Truncate: time.Second},
+			time.Date(2017, 10, 21, 4, 4, 56, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		out := test.policy.Normalize(in)
+		if !out.Equal(test.out) || out.Location() != test.out.Location() {
+			t.Errorf("%+v: Normalize(%v) = %v, want %v", test.policy, in, out, test.out)
+		}
+	}
+}
+
+func TestTimePolicyMonotonic(t *testing.T) {
+	now := time.Now()
+	if out := (&TimePolicy{}).Normalize(now); out != now {
+		t.Errorf("Normalize(%v) = %v: expected time to be unchanged", now, out)
+	}
+	for _, p := range []TimePolicy{{StripMonotonic: true}, {Truncate: time.Microsecond}} {
+		// Times with a monotonic clock reading include "m=" when printed.
+		if out := p.Normalize(now); out.String() != now.Round(0).Truncate(p.Truncate).String() {
+			t.Errorf("%+v: Normalize(%v) = %v: expected monotonic clock reading to be stripped",
+				p, now, out)
+		}
+	}
+}
+
+func TestDefaultTimePolicy(t *testing.T) {
+	orig := DefaultTimePolicy
+	defer func() { DefaultTimePolicy = orig }()
+
+	DefaultTimePolicy = TimePolicy{Location: time.UTC, Truncate: time.Millisecond}
+	in := time.Date(2017, 10, 20, 23, 4, 56, 123456789, time.FixedZone("EST", -5*3600))
+	want := time.Date(2017, 10, 21, 4, 4, 56, 123000000, time.UTC)
+
+	var v Time
+	if err := v.Scan(in); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Valid || v.Time != want {
+		t.Errorf("Scan(%v) = %v, want %v", in, v.Time, want)
+	}
+
+	dv, err := NewTime(in).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, ok := dv.(time.Time); !ok || out != want {
+		t.Errorf("Value(%v) = %v, want %v", in, dv, want)
+	}
+
+	if err := v.Scan(nil); err != nil || v.Valid {
+		t.Errorf("Scan(nil) = %+v, %v: want invalid Time", v, err)
+	}
+	if dv, err := (Time{}).Value(); dv != nil || err != nil {
+		t.Errorf("Value(Time{}) = %v, %v: want nil, nil", dv, err)
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		in  string
+		out time.Time
+		err error
+	}{
+		{"2017-10-20", time.Date(2017, 10, 20, 0, 0, 0, 0, time.UTC), nil},
+		{"2017-10-20 23:04:56", time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC), nil},
+		{"2017-10-20 23:04:56.1", time.Date(2017, 10, 20, 23, 4, 56, 100000000, time.UTC), nil},
+		{"2017-10-20 23:04:56.123456", time.Date(2017, 10, 20, 23, 4, 56, 123456000, time.UTC), nil},
+		{"2024-02-29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), nil},
+		{"0000-01-01", time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+		{"0000-00-00", time.Time{}, ErrZeroDate},
+		{"0000-00-00 00:00:00", time.Time{}, ErrZeroDate},
+		{"0000-00-00 00:00:00.000000", time.Time{}, ErrZeroDate},
+		{"2024-02-30", time.Time{}, ErrInvalidDate},
+		{"2023-02-29 00:00:00", time.Time{}, ErrInvalidDate},
+		{"2024-00-10", time.Time{}, ErrInvalidDate},
+		{"2024-01-00", time.Time{}, ErrInvalidDate},
+		{"2024-13-01", time.Time{}, ErrInvalidDate},
+		{"2024-04-31", time.Time{}, ErrInvalidDate},
+	}
+	for _, test := range tests {
+		out, err := parseDateTime(test.in)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("parseDateTime(%q) = %v, %v want error %v", test.in, out, err, test.err)
+			}
+			continue
+		}
+		if err != nil || out != test.out {
+			t.Errorf("parseDateTime(%q) = %v, %v want %v", test.in, out, err, test.out)
+		}
+	}
+	for _, in := range []string{
+		"", "2017", "2017-10-2", "2017/10/20", "2017-10-20 23:04",
+		"2017-10-20 23:04:56.", "2017-10-20 23:04:56.1234567", "2017-10-20 24:00:00",
+		"2017-10-20 23:60:00", "2017-1a-20", "2017-10-20x23:04:56",
+	} {
+		if _, err := parseDateTime(in); err == nil {
+			t.Errorf("parseDateTime(%q): expected error", in)
+		} else if _, ok := err.(*TimeError); ok {
+			t.Errorf("parseDateTime(%q) = %v: expected syntax error", in, err)
+		}
+	}
+}
+
+func TestTimePolicyInvalidDates(t *testing.T) {
+	want := time.Date(2017, 10, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		policy TimePolicy
+		in     interface{}
+		valid  bool
+		err    error
+	}{
+		{TimePolicy{}, "2017-10-20", true, nil},
+		{TimePolicy{}, "0000-00-00", true, nil},
+		{TimePolicy{}, time.Time{}, true, nil},
+		{TimePolicy{}, "2024-02-30", false, ErrInvalidDate},
+		{TimePolicy{ZeroDate: InvalidTimeZero}, []byte("0000-00-00 00:00:00"), true, nil},
+		{TimePolicy{ZeroDate: InvalidTimeNull}, []byte("0000-00-00 00:00:00"), false, nil},
+		{TimePolicy{ZeroDate: InvalidTimeNull}, time.Time{}, false, nil},
+		{TimePolicy{ZeroDate: InvalidTimeError}, "0000-00-00", false, ErrZeroDate},
+		{TimePolicy{ZeroDate: InvalidTimeError}, time.Time{}, false, ErrZeroDate},
+		{TimePolicy{InvalidDate: InvalidTimeError}, "2024-02-30", false, ErrInvalidDate},
+		{TimePolicy{InvalidDate: InvalidTimeNull}, "2024-02-30", false, nil},
+		{TimePolicy{InvalidDate: InvalidTimeNull}, "2024-00-00", false, nil},
+		{TimePolicy{InvalidDate: InvalidTimeZero}, []byte("2024-02-30 10:00:00"), true, nil},
+		{TimePolicy{InvalidDate: InvalidTimeNull}, "0000-00-00", true, nil},
+		{TimePolicy{ZeroDate: InvalidTimeNull}, "2024-02-30", false, ErrInvalidDate},
+	}
+	for _, test := range tests {
+		v := NewTime(time.Now())
+		err := test.policy.ScanTime(test.in, &v)
+		if test.err != nil {
+			var terr *TimeError
+			if !errors.As(err, &terr) || terr.Err != test.err {
+				t.Errorf("%+v: ScanTime(%#v) error = %v, want %v", test.policy, test.in, err, test.err)
+			}
+		} else if err != nil {
+			t.Errorf("%+v: ScanTime(%#v): %v", test.policy, test.in, err)
+		}
+		if v.Valid != test.valid {
+			t.Errorf("%+v: ScanTime(%#v) Valid = %t, want %t", test.policy, test.in, v.Valid, test.valid)
+		}
+		if v.Valid && v.Time != want && !v.Time.IsZero() {
+			t.Errorf("%+v: ScanTime(%#v) = %v, want %v or the zero time", test.policy, test.in, v.Time, want)
+		}
+	}
+	v := NewTime(want)
+	if err := (&TimePolicy{}).ScanTime(1, &v); err == nil || v.Valid {
+		t.Errorf("ScanTime(1) = %+v, %v: expected error", v, err)
+	}
+}
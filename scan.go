@@ -0,0 +1,338 @@
+package null
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// An UnmatchedColumnsError is returned by ScanStruct and ScanAll when columns
+// of the rows do not match a field of the struct. The columns are discarded
+// and the rest of the row is still scanned.
+type UnmatchedColumnsError struct {
+	Type    string   // the struct type
+	Columns []string // the unmatched columns
+}
+
+func (e *UnmatchedColumnsError) Error() string {
+	return "null: columns do not match a field of " + e.Type + ": " +
+		strings.Join(e.Columns, ", ")
+}
+
+// A structPlan maps column names to the fields of a struct type.
+type structPlan struct {
+	fields  map[string][]int // column name => field index
+	tagged  map[string][]int // lower case column name => field index
+	folded  map[string][]int // lower case field name => field index
+	columns []string         // column names, in field order
+}
+
+// A planKey identifies the structPlan of a struct type for a list of tags.
+type planKey struct {
+	t    reflect.Type
+	tags string
+}
+
+var structPlans sync.Map // map[planKey]*structPlan
+
+// planFor, returns the cached structPlan of struct type t. Column names are
+// read from the first of the struct tags tags that is set on a field.
+func planFor(t reflect.Type, tags ...string) *structPlan {
+	key := planKey{t, strings.Join(tags, ",")}
+	if p, ok := structPlans.Load(key); ok {
+		return p.(*structPlan)
+	}
+	p := &structPlan{
+		fields: make(map[string][]int),
+		tagged: make(map[string][]int),
+		folded: make(map[string][]int),
+	}
+	p.add(t, nil, tags)
+	seen := make(map[string]bool)
+	p.addColumns(t, tags, seen)
+	v, _ := structPlans.LoadOrStore(key, p)
+	return v.(*structPlan)
+}
+
+// scannerType, is the type of sql.Scanner.
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// fieldTag, returns the name in the first of tags set on field f, options
+// following a comma, such as "name,omitempty", are discarded.
+func fieldTag(f reflect.StructField, tags []string) string {
+	for _, tag := range tags {
+		if v := f.Tag.Get(tag); v != "" {
+			if i := strings.IndexByte(v, ','); i >= 0 {
+				v = v[:i]
+			}
+			return v
+		}
+	}
+	return ""
+}
+
+// embeddedStruct, returns the struct type of field f if it is an untagged
+// embedded struct whose fields are mapped to columns, which is the case
+// unless the struct is a sql.Scanner, such as the types of this package.
+func embeddedStruct(f reflect.StructField, tag string) (reflect.Type, bool) {
+	if !f.Anonymous || tag != "" {
+		return nil, false
+	}
+	ft := f.Type
+	if ft.Kind() == reflect.Ptr {
+		ft = ft.Elem()
+	}
+	if ft.Kind() != reflect.Struct || reflect.PtrTo(ft).Implements(scannerType) {
+		return nil, false
+	}
+	return ft, true
+}
+
+// add, adds the fields of struct type t, found at index, to plan p. Fields of
+// embedded structs are added after the other fields of t so that fields
+// closer to the root take precedence.
+func (p *structPlan) add(t reflect.Type, index []int, tags []string) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := fieldTag(f, tags)
+		if tag == "-" {
+			continue
+		}
+		if _, ok := embeddedStruct(f, tag); ok {
+			embedded = append(embedded, f)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		fi := append(append([]int(nil), index...), i)
+		if tag != "" {
+			if _, dup := p.fields[tag]; !dup {
+				p.fields[tag] = fi
+			}
+			if name := strings.ToLower(tag); p.tagged[name] == nil {
+				p.tagged[name] = fi
+			}
+		} else if name := strings.ToLower(f.Name); p.folded[name] == nil {
+			p.folded[name] = fi
+		}
+	}
+	for _, f := range embedded {
+		if f.Type.Kind() == reflect.Ptr {
+			// Pointers to embedded structs are not allocated.
+			continue
+		}
+		p.add(f.Type, append(append([]int(nil), index...), f.Index[0]), tags)
+	}
+}
+
+// addColumns, adds the column names of the fields of struct type t to plan p
+// in the order the fields are declared.
+func (p *structPlan) addColumns(t reflect.Type, tags []string, seen map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := fieldTag(f, tags)
+		if tag == "-" {
+			continue
+		}
+		if ft, ok := embeddedStruct(f, tag); ok {
+			if f.Type.Kind() != reflect.Ptr {
+				p.addColumns(ft, tags, seen)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = f.Name
+		}
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			p.columns = append(p.columns, name)
+		}
+	}
+}
+
+// field, returns the index of the field for column name, which is the
+// field tagged with the name or, if there is none, the field whose tag or,
+// for untagged fields, name matches the name ignoring case. Tagged fields
+// take precedence over untagged fields.
+func (p *structPlan) field(name string) ([]int, bool) {
+	if fi, ok := p.fields[name]; ok {
+		return fi, true
+	}
+	name = strings.ToLower(name)
+	if fi, ok := p.tagged[name]; ok {
+		return fi, true
+	}
+	fi, ok := p.folded[name]
+	return fi, ok
+}
+
+// A rowScanner scans the columns of rows into a struct.
+type rowScanner struct {
+	index     [][]int // field index of each column, nil if unmatched
+	dest      []interface{}
+	unmatched *UnmatchedColumnsError
+}
+
+func newRowScanner(rows *sql.Rows, t reflect.Type) (*rowScanner, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	p := planFor(t, "db")
+	s := &rowScanner{
+		index: make([][]int, len(columns)),
+		dest:  make([]interface{}, len(columns)),
+	}
+	for i, name := range columns {
+		if fi, ok := p.field(name); ok {
+			s.index[i] = fi
+			continue
+		}
+		if s.unmatched == nil {
+			s.unmatched = &UnmatchedColumnsError{Type: t.String()}
+		}
+		s.unmatched.Columns = append(s.unmatched.Columns, name)
+		s.dest[i] = new(interface{})
+	}
+	return s, nil
+}
+
+// scanCache, holds the rowScanner of the rows last scanned by ScanStruct so
+// that the columns are matched to fields once per rows, not once per row.
+var scanCache struct {
+	sync.Mutex
+	rows *sql.Rows
+	t    reflect.Type
+	s    *rowScanner
+}
+
+// cachedRowScanner, returns the cached rowScanner of rows and struct type t,
+// or a new one if there is none. The rowScanner is removed from the cache
+// until it is returned by releaseRowScanner.
+func cachedRowScanner(rows *sql.Rows, t reflect.Type) (*rowScanner, error) {
+	scanCache.Lock()
+	if s := scanCache.s; s != nil && scanCache.rows == rows && scanCache.t == t {
+		scanCache.s = nil
+		scanCache.Unlock()
+		return s, nil
+	}
+	scanCache.Unlock()
+	return newRowScanner(rows, t)
+}
+
+// releaseRowScanner, caches rowScanner s of rows and struct type t.
+func releaseRowScanner(rows *sql.Rows, t reflect.Type, s *rowScanner) {
+	scanCache.Lock()
+	scanCache.rows = rows
+	scanCache.t = t
+	scanCache.s = s
+	scanCache.Unlock()
+}
+
+// scan, scans the current row of rows into the struct v.
+func (s *rowScanner) scan(rows *sql.Rows, v reflect.Value) error {
+	for i, fi := range s.index {
+		if fi != nil {
+			s.dest[i] = v.FieldByIndex(fi).Addr().Interface()
+		}
+	}
+	return rows.Scan(s.dest...)
+}
+
+var errScanDest = errors.New("null: ScanStruct: destination must be a non-nil pointer to a struct")
+
+// ScanStruct, scans the current row of rows into the struct pointed to by
+// dst. Columns are matched to fields by the name in the `db:"name"` struct
+// tag or, for untagged fields, by the field name, ignoring case if no tag
+// matches exactly. Options following the name, such as `db:"name,omitempty"`,
+// are ignored. Fields tagged `db:"-"` are ignored and the fields of embedded
+// structs are matched as if they were fields of dst.
+//
+// Like sql.Rows.Scan, rows.Next must be called before ScanStruct. If columns
+// do not match a field an *UnmatchedColumnsError is returned after the row
+// is scanned.
+//
+// The columns of rows are matched to fields by the first call and reused by
+// following calls with the same rows and struct type, so all result sets of
+// rows read with rows.NextResultSet must have the same columns; use ScanAll
+// for each result set otherwise.
+func ScanStruct(rows *sql.Rows, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errScanDest
+	}
+	t := v.Elem().Type()
+	s, err := cachedRowScanner(rows, t)
+	if err != nil {
+		return err
+	}
+	err = s.scan(rows, v.Elem())
+	releaseRowScanner(rows, t, s)
+	if err != nil {
+		return err
+	}
+	if s.unmatched != nil {
+		return s.unmatched
+	}
+	return nil
+}
+
+var errScanAllDest = errors.New("null: ScanAll: destination must be a pointer to a slice of structs or struct pointers")
+
+// ScanAll, scans all of the rows into the slice pointed to by dst, which
+// must be a slice of structs or of pointers to structs, see ScanStruct. The
+// scanned rows are appended to the slice and rows is closed. The columns are
+// matched to fields once, for the first result set of rows.
+//
+// If a row cannot be scanned the error is returned and the rows scanned
+// before it are appended to the slice. If columns do not match a field an
+// *UnmatchedColumnsError is returned after all rows are scanned.
+func ScanAll(rows *sql.Rows, dst interface{}) error {
+	defer rows.Close()
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errScanAllDest
+	}
+	slice := v.Elem()
+	elem := slice.Type().Elem()
+	isPtr := elem.Kind() == reflect.Ptr
+	if isPtr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return errScanAllDest
+	}
+	s, err := newRowScanner(rows, elem)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		row := reflect.New(elem)
+		if err := s.scan(rows, row.Elem()); err != nil {
+			v.Elem().Set(slice)
+			return err
+		}
+		if isPtr {
+			slice = reflect.Append(slice, row)
+		} else {
+			slice = reflect.Append(slice, row.Elem())
+		}
+	}
+	v.Elem().Set(slice)
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if s.unmatched != nil {
+		return s.unmatched
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package null
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeTable, is a database/sql driver that returns the same rows for every
+// query.
+type fakeTable struct {
+	columns []string
+	types   []string       // database type names
+	scan    []reflect.Type // scan types, may be nil
+	rows    [][]driver.Value
+}
+
+func (t *fakeTable) open(tb testing.TB) *sql.Rows {
+	tb.Helper()
+	db := sql.OpenDB(t)
+	tb.Cleanup(func() { db.Close() })
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return rows
+}
+
+func (t *fakeTable) Connect(context.Context) (driver.Conn, error) { return fakeConn{t}, nil }
+func (t *fakeTable) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ t *fakeTable }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("fake: Begin") }
+
+type fakeStmt struct{ t *fakeTable }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("fake: Exec")
+}
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{t: s.t}, nil
+}
+
+type fakeRows struct {
+	t *fakeTable
+	n int
+}
+
+func (r *fakeRows) Columns() []string { return r.t.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.n >= len(r.t.rows) {
+		return io.EOF
+	}
+	copy(dest, r.t.rows[r.n])
+	r.n++
+	return nil
+}
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(i int) string {
+	if r.t.types == nil {
+		return ""
+	}
+	return r.t.types[i]
+}
+
+func (r *fakeRows) ColumnTypeScanType(i int) reflect.Type {
+	if r.t.scan == nil || r.t.scan[i] == nil {
+		return reflect.TypeOf(new(interface{})).Elem()
+	}
+	return r.t.scan[i]
+}
+
+var userTable = fakeTable{
+	columns: []string{"id", "name", "score", "active", "created", "Note"},
+	rows: [][]driver.Value{
+		{int64(1), []byte("alice"), float64(1.5), true, time.Unix(1, 0).UTC(), "x"},
+		{int64(2), nil, nil, nil, nil, nil},
+	},
+}
+
+type userBase struct {
+	ID      Int  `db:"id"`
+	Created Time `db:"created"`
+}
+
+type user struct {
+	userBase
+	Name    String `db:"name"`
+	Score   Float64
+	Active  Bool `db:"active"`
+	Note    *string
+	Ignored String `db:"-"`
+	private int
+}
+
+func TestScanStruct(t *testing.T) {
+	rows := userTable.open(t)
+	defer rows.Close()
+
+	var got []user
+	for rows.Next() {
+		var u user
+		if err := ScanStruct(rows, &u); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, u)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	note := "x"
+	want := []user{
+		{
+			userBase: userBase{ID: NewInt(1), Created: NewTime(time.Unix(1, 0).UTC())},
+			Name:     NewString("alice"),
+			Score:    NewFloat64(1.5),
+			Active:   NewBool(true),
+			Note:     &note,
+		},
+		{userBase: userBase{ID: NewInt(2)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanStruct:\ngot:  %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestScanStructTags(t *testing.T) {
+	type tagged struct {
+		ID    Int     `db:"ID,omitempty"`
+		Name  String  `db:"Name"`
+		Score Float64 `db:",omitempty"`
+		Other String  `db:"active,omitempty"`
+		Skip  String  `db:"-,"`
+	}
+	table := fakeTable{
+		columns: []string{"id", "name", "SCORE", "active"},
+		rows:    [][]driver.Value{{int64(1), "a", 1.5, "b"}, {int64(2), "c", 2.5, "d"}},
+	}
+	rows := table.open(t)
+	defer rows.Close()
+
+	var got []tagged
+	for rows.Next() {
+		var v tagged
+		if err := ScanStruct(rows, &v); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	want := []tagged{
+		{ID: NewInt(1), Name: NewString("a"), Score: NewFloat64(1.5), Other: NewString("b")},
+		{ID: NewInt(2), Name: NewString("c"), Score: NewFloat64(2.5), Other: NewString("d")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanStruct:\ngot:  %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestScanStructRowsChanged(t *testing.T) {
+	// The columns matched for one rows must not be used for other rows.
+	tables := []fakeTable{
+		{columns: []string{"id", "name"}, rows: [][]driver.Value{{int64(1), "a"}}},
+		{columns: []string{"name", "id"}, rows: [][]driver.Value{{"b", int64(2)}}},
+	}
+	want := []user{
+		{userBase: userBase{ID: NewInt(1)}, Name: NewString("a")},
+		{userBase: userBase{ID: NewInt(2)}, Name: NewString("b")},
+	}
+	for i := range tables {
+		rows := tables[i].open(t)
+		for rows.Next() {
+			var u user
+			if err := ScanStruct(rows, &u); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(u, want[i]) {
+				t.Errorf("ScanStruct(%q) = %+v want %+v", tables[i].columns, u, want[i])
+			}
+		}
+		rows.Close()
+	}
+}
+
+func TestScanAll(t *testing.T) {
+	var users []*user
+	if err := ScanAll(userTable.open(t), &users); err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].Name != NewString("alice") || users[1].ID != NewInt(2) {
+		t.Errorf("ScanAll = %+v", users)
+	}
+
+	var values []user
+	if err := ScanAll(userTable.open(t), &values); err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0].Score != NewFloat64(1.5) || values[1].Score.Valid {
+		t.Errorf("ScanAll = %+v", values)
+	}
+}
+
+func TestScanUnmatchedColumns(t *testing.T) {
+	type partial struct {
+		ID   Int    `db:"id"`
+		Name String `db:"name"`
+	}
+	var rows []partial
+	err := ScanAll(userTable.open(t), &rows)
+	var uerr *UnmatchedColumnsError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("ScanAll error = %v want *UnmatchedColumnsError", err)
+	}
+	want := []string{"score", "active", "created", "Note"}
+	if !reflect.DeepEqual(uerr.Columns, want) {
+		t.Errorf("unmatched columns = %q want %q", uerr.Columns, want)
+	}
+	if len(rows) != 2 || rows[0].Name != NewString("alice") {
+		t.Errorf("ScanAll = %+v: want all rows scanned", rows)
+	}
+}
+
+func TestScanDestErrors(t *testing.T) {
+	rows := userTable.open(t)
+	defer rows.Close()
+	rows.Next()
+	var u user
+	for _, dst := range []interface{}{u, (*user)(nil), new(int)} {
+		if err := ScanStruct(rows, dst); err != errScanDest {
+			t.Errorf("ScanStruct(%T) = %v want %v", dst, err, errScanDest)
+		}
+	}
+	for _, dst := range []interface{}{[]user{}, new([]int), &u} {
+		if err := ScanAll(userTable.open(t), dst); err != errScanAllDest {
+			t.Errorf("ScanAll(%T) = %v want %v", dst, err, errScanAllDest)
+		}
+	}
+}
+
+func TestScanScanError(t *testing.T) {
+	table := fakeTable{
+		columns: []string{"id"},
+		rows:    [][]driver.Value{{"abc"}},
+	}
+	var rows []user
+	err := ScanAll(table.open(t), &rows)
+	if !errors.Is(err, ErrSyntax) {
+		t.Errorf("ScanAll error = %v want %v", err, ErrSyntax)
+	}
+
+	// Rows scanned before the error are returned.
+	table.rows = [][]driver.Value{{int64(1)}, {int64(2)}, {"abc"}, {int64(4)}}
+	rows = rows[:0]
+	err = ScanAll(table.open(t), &rows)
+	if !errors.Is(err, ErrSyntax) {
+		t.Errorf("ScanAll error = %v want %v", err, ErrSyntax)
+	}
+	if len(rows) != 2 || rows[0].ID != NewInt(1) || rows[1].ID != NewInt(2) {
+		t.Errorf("ScanAll = %+v: want the first 2 rows", rows)
+	}
+}
+
+func BenchmarkScanStruct(b *testing.B) {
+	rows := userTable.open(b)
+	defer rows.Close()
+	rows.Next()
+	var u user
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := ScanStruct(rows, &u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanAll(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var users []user
+		if err := ScanAll(userTable.open(b), &users); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package null
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// This file converts between the types of this package and the sql.NullX
+// types and sql.Null[T] of database/sql. All conversions are lossless, except
+// that an Int holds an int, which is 32 bits on 32-bit platforms.
+
+// IntFromSQL, returns the Int of sql.NullInt64 n.
+func IntFromSQL(n sql.NullInt64) Int {
+	return Int{Int: int(n.Int64), Valid: n.Valid}
+}
+
+// FromSQL, sets Int i to the value of sql.NullInt64 n.
+func (i *Int) FromSQL(n sql.NullInt64) {
+	*i = IntFromSQL(n)
+}
+
+// ToSQL, returns Int i as a sql.NullInt64.
+func (i Int) ToSQL() sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(i.Int), Valid: i.Valid}
+}
+
+// ToNull, returns Int i as a sql.Null[int].
+func (i Int) ToNull() sql.Null[int] {
+	return sql.Null[int]{V: i.Int, Valid: i.Valid}
+}
+
+// Uint64FromSQL, returns the Uint64 of sql.Null[uint64] n.
+func Uint64FromSQL(n sql.Null[uint64]) Uint64 {
+	return Uint64{Uint64: n.V, Valid: n.Valid}
+}
+
+// FromSQL, sets Uint64 u to the value of sql.Null[uint64] n.
+func (u *Uint64) FromSQL(n sql.Null[uint64]) {
+	*u = Uint64FromSQL(n)
+}
+
+// ToSQL, returns Uint64 u as a sql.Null[uint64]. Since database/sql does not
+// accept uint64 values, the sql.Null[uint64] can only be passed to drivers
+// that do, see Converter.
+func (u Uint64) ToSQL() sql.Null[uint64] {
+	return sql.Null[uint64]{V: u.Uint64, Valid: u.Valid}
+}
+
+// ToNull, returns Uint64 u as a sql.Null[uint64], it is the same as ToSQL.
+func (u Uint64) ToNull() sql.Null[uint64] {
+	return u.ToSQL()
+}
+
+// Float64FromSQL, returns the Float64 of sql.NullFloat64 n.
+func Float64FromSQL(n sql.NullFloat64) Float64 {
+	return Float64{Float64: n.Float64, Valid: n.Valid}
+}
+
+// FromSQL, sets Float64 f to the value of sql.NullFloat64 n.
+func (f *Float64) FromSQL(n sql.NullFloat64) {
+	*f = Float64FromSQL(n)
+}
+
+// ToSQL, returns Float64 f as a sql.NullFloat64.
+func (f Float64) ToSQL() sql.NullFloat64 {
+	return sql.NullFloat64{Float64: f.Float64, Valid: f.Valid}
+}
+
+// ToNull, returns Float64 f as a sql.Null[float64].
+func (f Float64) ToNull() sql.Null[float64] {
+	return sql.Null[float64]{V: f.Float64, Valid: f.Valid}
+}
+
+// Float32FromSQL, returns the Float32 of sql.Null[float32] n.
+func Float32FromSQL(n sql.Null[float32]) Float32 {
+	return Float32{Float32: n.V, Valid: n.Valid}
+}
+
+// FromSQL, sets Float32 f to the value of sql.Null[float32] n.
+func (f *Float32) FromSQL(n sql.Null[float32]) {
+	*f = Float32FromSQL(n)
+}
+
+// ToSQL, returns Float32 f as a sql.Null[float32].
+func (f Float32) ToSQL() sql.Null[float32] {
+	return sql.Null[float32]{V: f.Float32, Valid: f.Valid}
+}
+
+// ToNull, returns Float32 f as a sql.Null[float32], it is the same as ToSQL.
+func (f Float32) ToNull() sql.Null[float32] {
+	return f.ToSQL()
+}
+
+// StringFromSQL, returns the String of sql.NullString n.
+func StringFromSQL(n sql.NullString) String {
+	return String{String: n.String, Valid: n.Valid}
+}
+
+// FromSQL, sets String s to the value of sql.NullString n.
+func (s *String) FromSQL(n sql.NullString) {
+	*s = StringFromSQL(n)
+}
+
+// ToSQL, returns String s as a sql.NullString.
+func (s String) ToSQL() sql.NullString {
+	return sql.NullString{String: s.String, Valid: s.Valid}
+}
+
+// ToNull, returns String s as a sql.Null[string].
+func (s String) ToNull() sql.Null[string] {
+	return sql.Null[string]{V: s.String, Valid: s.Valid}
+}
+
+// UnescapedStringFromSQL, returns the UnescapedString of sql.NullString n.
+func UnescapedStringFromSQL(n sql.NullString) UnescapedString {
+	return UnescapedString(StringFromSQL(n))
+}
+
+// FromSQL, sets UnescapedString s to the value of sql.NullString n.
+func (s *UnescapedString) FromSQL(n sql.NullString) {
+	*s = UnescapedStringFromSQL(n)
+}
+
+// ToSQL, returns UnescapedString s as a sql.NullString.
+func (s UnescapedString) ToSQL() sql.NullString {
+	return String(s).ToSQL()
+}
+
+// ToNull, returns UnescapedString s as a sql.Null[string].
+func (s UnescapedString) ToNull() sql.Null[string] {
+	return String(s).ToNull()
+}
+
+// BoolFromSQL, returns the Bool of sql.NullBool n.
+func BoolFromSQL(n sql.NullBool) Bool {
+	return Bool{Bool: n.Bool, Valid: n.Valid}
+}
+
+// FromSQL, sets Bool b to the value of sql.NullBool n.
+func (b *Bool) FromSQL(n sql.NullBool) {
+	*b = BoolFromSQL(n)
+}
+
+// ToSQL, returns Bool b as a sql.NullBool.
+func (b Bool) ToSQL() sql.NullBool {
+	return sql.NullBool{Bool: b.Bool, Valid: b.Valid}
+}
+
+// ToNull, returns Bool b as a sql.Null[bool].
+func (b Bool) ToNull() sql.Null[bool] {
+	return sql.Null[bool]{V: b.Bool, Valid: b.Valid}
+}
+
+// TimeFromSQL, returns the Time of sql.NullTime n.
+func TimeFromSQL(n sql.NullTime) Time {
+	return Time{Time: n.Time, Valid: n.Valid}
+}
+
+// FromSQL, sets Time t to the value of sql.NullTime n.
+func (t *Time) FromSQL(n sql.NullTime) {
+	*t = TimeFromSQL(n)
+}
+
+// ToSQL, returns Time t as a sql.NullTime.
+func (t Time) ToSQL() sql.NullTime {
+	return sql.NullTime{Time: t.Time, Valid: t.Valid}
+}
+
+// ToNull, returns Time t as a sql.Null[time.Time].
+func (t Time) ToNull() sql.Null[time.Time] {
+	return sql.Null[time.Time]{V: t.Time, Valid: t.Valid}
+}
+
+// Signed, is the set of signed integer types accepted by IntFromNull.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned, is the set of unsigned integer types accepted by Uint64FromNull.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// IntFromNull, returns the Int of sql.Null[T] n.
+func IntFromNull[T Signed](n sql.Null[T]) Int {
+	return Int{Int: int(n.V), Valid: n.Valid}
+}
+
+// Uint64FromNull, returns the Uint64 of sql.Null[T] n.
+func Uint64FromNull[T Unsigned](n sql.Null[T]) Uint64 {
+	return Uint64{Uint64: uint64(n.V), Valid: n.Valid}
+}
+
+// Float64FromNull, returns the Float64 of sql.Null[T] n.
+func Float64FromNull[T ~float32 | ~float64](n sql.Null[T]) Float64 {
+	return Float64{Float64: float64(n.V), Valid: n.Valid}
+}
+
+// Float32FromNull, returns the Float32 of sql.Null[T] n.
+func Float32FromNull[T ~float32](n sql.Null[T]) Float32 {
+	return Float32{Float32: float32(n.V), Valid: n.Valid}
+}
+
+// StringFromNull, returns the String of sql.Null[T] n.
+func StringFromNull[T ~string | ~[]byte](n sql.Null[T]) String {
+	return String{String: string(n.V), Valid: n.Valid}
+}
+
+// BoolFromNull, returns the Bool of sql.Null[T] n.
+func BoolFromNull[T ~bool](n sql.Null[T]) Bool {
+	return Bool{Bool: bool(n.V), Valid: n.Valid}
+}
+
+// TimeFromNull, returns the Time of sql.Null[time.Time] n.
+func TimeFromNull(n sql.Null[time.Time]) Time {
+	return Time{Time: n.V, Valid: n.Valid}
+}
+
+// FromNull, returns the type of this package matching the type T of n: an
+// Int for signed integers, a Uint64 for unsigned integers, a Float32 for
+// float32, a Float64 for float64, a String for strings and byte slices, a
+// Bool for bool and a Time for time.Time. Named types are matched by their
+// underlying type. An error wrapping ErrUnsupportedType is returned for all
+// other types.
+func FromNull[T any](n sql.Null[T]) (interface{}, error) {
+	if t, ok := interface{}(n.V).(time.Time); ok {
+		return Time{Time: t, Valid: n.Valid}, nil
+	}
+	rv := reflect.ValueOf(&n.V).Elem()
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int{Int: int(rv.Int()), Valid: n.Valid}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Uint64{Uint64: rv.Uint(), Valid: n.Valid}, nil
+	case reflect.Float32:
+		return Float32{Float32: float32(rv.Float()), Valid: n.Valid}, nil
+	case reflect.Float64:
+		return Float64{Float64: rv.Float(), Valid: n.Valid}, nil
+	case reflect.String:
+		return String{String: rv.String(), Valid: n.Valid}, nil
+	case reflect.Bool:
+		return Bool{Bool: rv.Bool(), Valid: n.Valid}, nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return String{String: string(rv.Bytes()), Valid: n.Valid}, nil
+		}
+	}
+	return nil, fmt.Errorf("null: cannot convert %T: %w", n, ErrUnsupportedType)
+}
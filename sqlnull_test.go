@@ -0,0 +1,162 @@
+package null
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSQLRoundTrip(t *testing.T) {
+	now := time.Date(2006, 1, 2, 15, 4, 5, 999999999, time.FixedZone("", 3600))
+	for _, valid := range []bool{true, false} {
+		i := Int{Int: math.MaxInt32, Valid: valid}
+		if got := IntFromSQL(i.ToSQL()); got != i {
+			t.Errorf("IntFromSQL(%+v.ToSQL()) = %+v", i, got)
+		}
+		if got := IntFromNull(i.ToNull()); got != i {
+			t.Errorf("IntFromNull(%+v.ToNull()) = %+v", i, got)
+		}
+
+		u := Uint64{Uint64: math.MaxUint64, Valid: valid}
+		if got := Uint64FromSQL(u.ToSQL()); got != u {
+			t.Errorf("Uint64FromSQL(%+v.ToSQL()) = %+v", u, got)
+		}
+		if got := Uint64FromNull(u.ToNull()); got != u {
+			t.Errorf("Uint64FromNull(%+v.ToNull()) = %+v", u, got)
+		}
+
+		f64 := Float64{Float64: math.SmallestNonzeroFloat64, Valid: valid}
+		if got := Float64FromSQL(f64.ToSQL()); got != f64 {
+			t.Errorf("Float64FromSQL(%+v.ToSQL()) = %+v", f64, got)
+		}
+		if got := Float64FromNull(f64.ToNull()); got != f64 {
+			t.Errorf("Float64FromNull(%+v.ToNull()) = %+v", f64, got)
+		}
+
+		f32 := Float32{Float32: math.MaxFloat32, Valid: valid}
+		if got := Float32FromSQL(f32.ToSQL()); got != f32 {
+			t.Errorf("Float32FromSQL(%+v.ToSQL()) = %+v", f32, got)
+		}
+		if got := Float32FromNull(f32.ToNull()); got != f32 {
+			t.Errorf("Float32FromNull(%+v.ToNull()) = %+v", f32, got)
+		}
+
+		s := String{String: "a\x00b", Valid: valid}
+		if got := StringFromSQL(s.ToSQL()); got != s {
+			t.Errorf("StringFromSQL(%+v.ToSQL()) = %+v", s, got)
+		}
+		if got := StringFromNull(s.ToNull()); got != s {
+			t.Errorf("StringFromNull(%+v.ToNull()) = %+v", s, got)
+		}
+
+		us := UnescapedString{String: "<a>", Valid: valid}
+		if got := UnescapedStringFromSQL(us.ToSQL()); got != us {
+			t.Errorf("UnescapedStringFromSQL(%+v.ToSQL()) = %+v", us, got)
+		}
+
+		b := Bool{Bool: true, Valid: valid}
+		if got := BoolFromSQL(b.ToSQL()); got != b {
+			t.Errorf("BoolFromSQL(%+v.ToSQL()) = %+v", b, got)
+		}
+		if got := BoolFromNull(b.ToNull()); got != b {
+			t.Errorf("BoolFromNull(%+v.ToNull()) = %+v", b, got)
+		}
+
+		tm := Time{Time: now, Valid: valid}
+		if got := TimeFromSQL(tm.ToSQL()); got != tm {
+			t.Errorf("TimeFromSQL(%+v.ToSQL()) = %+v", tm, got)
+		}
+		if got := TimeFromNull(tm.ToNull()); got != tm {
+			t.Errorf("TimeFromNull(%+v.ToNull()) = %+v", tm, got)
+		}
+	}
+}
+
+func TestFromSQLMethods(t *testing.T) {
+	var i Int
+	i.FromSQL(sql.NullInt64{Int64: 7, Valid: true})
+	var u Uint64
+	u.FromSQL(sql.Null[uint64]{V: 8, Valid: true})
+	var f64 Float64
+	f64.FromSQL(sql.NullFloat64{Float64: 1.5, Valid: true})
+	var f32 Float32
+	f32.FromSQL(sql.Null[float32]{V: 2.5, Valid: true})
+	var s String
+	s.FromSQL(sql.NullString{String: "s", Valid: true})
+	var us UnescapedString
+	us.FromSQL(sql.NullString{String: "us", Valid: true})
+	var b Bool
+	b.FromSQL(sql.NullBool{Bool: true, Valid: true})
+	var tm Time
+	tm.FromSQL(sql.NullTime{Time: time.Unix(1, 0), Valid: true})
+
+	if i != NewInt(7) || u != NewUint64(8) || f64 != NewFloat64(1.5) ||
+		f32 != NewFloat32(2.5) || s != NewString("s") || us != NewUnescapedString("us") ||
+		b != NewBool(true) || tm != NewTime(time.Unix(1, 0)) {
+		t.Errorf("FromSQL: %+v %+v %+v %+v %+v %+v %+v %+v", i, u, f64, f32, s, us, b, tm)
+	}
+
+	// FromSQL must reset previously valid values.
+	i.FromSQL(sql.NullInt64{})
+	if i.Valid || i.Int != 0 {
+		t.Errorf("FromSQL(NULL) = %+v", i)
+	}
+}
+
+type myInt16 int16
+
+func TestFromNull(t *testing.T) {
+	tests := []struct {
+		in  interface{}
+		out interface{}
+	}{
+		{sql.Null[int8]{V: -8, Valid: true}, NewInt(-8)},
+		{sql.Null[myInt16]{V: 16, Valid: true}, NewInt(16)},
+		{sql.Null[int64]{}, Int{}},
+		{sql.Null[uint32]{V: math.MaxUint32, Valid: true}, NewUint64(math.MaxUint32)},
+		{sql.Null[uint64]{V: math.MaxUint64, Valid: true}, NewUint64(math.MaxUint64)},
+		{sql.Null[float32]{V: 1.5, Valid: true}, NewFloat32(1.5)},
+		{sql.Null[float64]{V: 2.5, Valid: true}, NewFloat64(2.5)},
+		{sql.Null[string]{V: "s", Valid: true}, NewString("s")},
+		{sql.Null[[]byte]{V: []byte("b"), Valid: true}, NewString("b")},
+		{sql.Null[bool]{V: true, Valid: true}, NewBool(true)},
+		{sql.Null[time.Time]{V: time.Unix(1, 0), Valid: true}, NewTime(time.Unix(1, 0))},
+	}
+	for _, test := range tests {
+		var out interface{}
+		var err error
+		switch n := test.in.(type) {
+		case sql.Null[int8]:
+			out, err = FromNull(n)
+		case sql.Null[myInt16]:
+			out, err = FromNull(n)
+		case sql.Null[int64]:
+			out, err = FromNull(n)
+		case sql.Null[uint32]:
+			out, err = FromNull(n)
+		case sql.Null[uint64]:
+			out, err = FromNull(n)
+		case sql.Null[float32]:
+			out, err = FromNull(n)
+		case sql.Null[float64]:
+			out, err = FromNull(n)
+		case sql.Null[string]:
+			out, err = FromNull(n)
+		case sql.Null[[]byte]:
+			out, err = FromNull(n)
+		case sql.Null[bool]:
+			out, err = FromNull(n)
+		case sql.Null[time.Time]:
+			out, err = FromNull(n)
+		}
+		if err != nil || out != test.out {
+			t.Errorf("FromNull(%#v) = %#v, %v want %#v", test.in, out, err, test.out)
+		}
+	}
+
+	if _, err := FromNull(sql.Null[struct{}]{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("FromNull(sql.Null[struct{}]) error = %v want %v", err, ErrUnsupportedType)
+	}
+}
@@ -34,8 +34,6 @@
 package null
 
 import (
-	"bytes"
-	"errors"
 	"strconv"
 	"unicode"
 	"unicode/utf16"
@@ -44,12 +42,12 @@ import (
 
 const hex = "0123456789abcdef"
 
-// marshalString, marshals string s into a JSON value.
-func marshalString(s string) ([]byte, error) {
-	const escapeHTML = true // TODO (CEV): Add as field to String
+// appendString, appends the JSON encoding of string s to dst using the
+// escaping rules of Encoder enc.
+func (enc *Encoder) appendString(dst []byte, s string) []byte {
+	escapeHTML := enc.EscapeHTML
 
-	var e bytes.Buffer
-	e.WriteByte('"')
+	dst = append(dst, '"')
 	start := 0
 	for i := 0; i < len(s); {
 		if b := s[i]; b < utf8.RuneSelf {
@@ -58,30 +56,24 @@ func marshalString(s string) ([]byte, error) {
 				continue
 			}
 			if start < i {
-				e.WriteString(s[start:i])
+				dst = append(dst, s[start:i]...)
 			}
 			switch b {
 			case '\\', '"':
-				e.WriteByte('\\')
-				e.WriteByte(b)
+				dst = append(dst, '\\', b)
 			case '\n':
-				e.WriteByte('\\')
-				e.WriteByte('n')
+				dst = append(dst, '\\', 'n')
 			case '\r':
-				e.WriteByte('\\')
-				e.WriteByte('r')
+				dst = append(dst, '\\', 'r')
 			case '\t':
-				e.WriteByte('\\')
-				e.WriteByte('t')
+				dst = append(dst, '\\', 't')
 			default:
 				// This encodes bytes < 0x20 except for \t, \n and \r.
 				// If escapeHTML is set, it also escapes <, >, and &
 				// because they can lead to security holes when
 				// user-controlled strings are rendered into JSON
 				// and served to some browsers.
-				e.WriteString(`\u00`)
-				e.WriteByte(hex[b>>4])
-				e.WriteByte(hex[b&0xF])
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
 			}
 			i++
 			start = i
@@ -90,9 +82,9 @@ func marshalString(s string) ([]byte, error) {
 		c, size := utf8.DecodeRuneInString(s[i:])
 		if c == utf8.RuneError && size == 1 {
 			if start < i {
-				e.WriteString(s[start:i])
+				dst = append(dst, s[start:i]...)
 			}
-			e.WriteString(`\ufffd`)
+			dst = append(dst, `\ufffd`...)
 			i += size
 			start = i
 			continue
@@ -102,14 +94,13 @@ func marshalString(s string) ([]byte, error) {
 		// They are both technically valid characters in JSON strings,
 		// but don't work in JSONP, which has to be evaluated as JavaScript,
 		// and can lead to security holes there. It is valid JSON to
-		// escape them, so we do so unconditionally.
+		// escape them, so we do so unless EscapeLineSeparators is disabled.
 		// See http://timelessrepo.com/json-isnt-a-javascript-subset for discussion.
-		if c == '\u2028' || c == '\u2029' {
+		if enc.EscapeLineSeparators && (c == '\u2028' || c == '\u2029') {
 			if start < i {
-				e.WriteString(s[start:i])
+				dst = append(dst, s[start:i]...)
 			}
-			e.WriteString(`\u202`)
-			e.WriteByte(hex[c&0xF])
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[c&0xF])
 			i += size
 			start = i
 			continue
@@ -117,10 +108,9 @@ func marshalString(s string) ([]byte, error) {
 		i += size
 	}
 	if start < len(s) {
-		e.WriteString(s[start:])
+		dst = append(dst, s[start:]...)
 	}
-	e.WriteByte('"')
-	return e.Bytes(), nil
+	return append(dst, '"')
 }
 
 // getu4 decodes \uXXXX from the beginning of s, returning the hex value,
@@ -141,7 +131,7 @@ func getu4(s []byte) rune {
 func unmarshalString(s []byte) (t string, err error) {
 	var ok bool
 	if s, ok = unquoteBytes(s); !ok {
-		err = errors.New("null: error decoding string")
+		err = ErrSyntax
 	}
 	t = string(s)
 	return
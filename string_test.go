@@ -75,12 +75,7 @@ var encodeStringTests = []struct {
 
 func TestEncodeString(t *testing.T) {
 	for _, tt := range encodeStringTests {
-		b, err := marshalString(tt.in)
-		if err != nil {
-			t.Errorf("Marshal(%q): %v", tt.in, err)
-			continue
-		}
-		out := string(b)
+		out := string(DefaultEncoder.appendString(nil, tt.in))
 		if out != tt.out {
 			t.Errorf("Marshal(%q) = %#q, want %#q", tt.in, out, tt.out)
 		}
@@ -90,11 +85,7 @@ func TestEncodeString(t *testing.T) {
 func TestMarshalerEscaping(t *testing.T) {
 	const c = `"<&>"`
 	const want = `"\"\u003c\u0026\u003e\""`
-	b, err := marshalString(c)
-	if err != nil {
-		t.Fatalf("Marshal(c): %v", err)
-	}
-	if got := string(b); got != want {
+	if got := string(DefaultEncoder.appendString(nil, c)); got != want {
 		t.Errorf("Marshal(c) = %#q, want %#q", got, want)
 	}
 }
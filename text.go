@@ -0,0 +1,229 @@
+package null
+
+import (
+	"strconv"
+	"time"
+)
+
+// NullText, is the text representation of NULL used by MarshalText and
+// UnmarshalText, e.g. "NULL" or `\N`. By default NULL is represented by empty
+// text.
+//
+// Text equal to NullText always unmarshals as NULL, so with the default
+// NullText an empty String does not survive a round trip through text.
+//
+// NullText is not safe to modify while values are being marshaled and should
+// only be changed during program initialization.
+var NullText = ""
+
+// nullText, returns if text is the text representation of NULL.
+func nullText(text []byte) bool {
+	return len(text) == len(NullText) && string(text) == NullText
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface.
+func (i Int) MarshalText() ([]byte, error) {
+	if i.Valid {
+		return strconv.AppendInt(make([]byte, 0, 20), int64(i.Int), 10), nil
+	}
+	return []byte(NullText), nil
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface.
+func (i *Int) UnmarshalText(text []byte) error {
+	if nullText(text) {
+		i.Int, i.Valid = 0, false
+		return nil
+	}
+	return i.parseText(text)
+}
+
+// parseText, parses the text of a valid Int, text equal to NullText is not
+// NULL.
+func (i *Int) parseText(text []byte) error {
+	n, err := parseInt(text, strconv.IntSize)
+	if err != nil {
+		i.Int, i.Valid = 0, false
+		return newUnmarshalError("null.Int", text, err)
+	}
+	i.Int, i.Valid = int(n), true
+	return nil
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface.
+func (u Uint64) MarshalText() ([]byte, error) {
+	if u.Valid {
+		return strconv.AppendUint(make([]byte, 0, 20), u.Uint64, 10), nil
+	}
+	return []byte(NullText), nil
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface.
+func (u *Uint64) UnmarshalText(text []byte) error {
+	if nullText(text) {
+		u.Uint64, u.Valid = 0, false
+		return nil
+	}
+	return u.parseText(text)
+}
+
+// parseText, parses the text of a valid Uint64, text equal to NullText is not
+// NULL.
+func (u *Uint64) parseText(text []byte) error {
+	n, err := parseUint(text, 64)
+	if err != nil {
+		u.Uint64, u.Valid = 0, false
+		return newUnmarshalError("null.Uint64", text, err)
+	}
+	u.Uint64, u.Valid = n, true
+	return nil
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface.
+func (f Float64) MarshalText() ([]byte, error) {
+	if f.Valid {
+		return appendFloat(make([]byte, 0, 24), f.Float64, 64)
+	}
+	return []byte(NullText), nil
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface.
+func (f *Float64) UnmarshalText(text []byte) error {
+	if nullText(text) {
+		f.Float64, f.Valid = 0, false
+		return nil
+	}
+	return f.parseText(text)
+}
+
+// parseText, parses the text of a valid Float64, text equal to NullText is not
+// NULL.
+func (f *Float64) parseText(text []byte) error {
+	n, err := parseFloat(text, 64)
+	if err != nil {
+		f.Float64, f.Valid = 0, false
+		return newUnmarshalError("null.Float64", text, err)
+	}
+	f.Float64, f.Valid = n, true
+	return nil
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface.
+func (f Float32) MarshalText() ([]byte, error) {
+	if f.Valid {
+		return appendFloat(make([]byte, 0, 16), float64(f.Float32), 32)
+	}
+	return []byte(NullText), nil
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface.
+func (f *Float32) UnmarshalText(text []byte) error {
+	if nullText(text) {
+		f.Float32, f.Valid = 0, false
+		return nil
+	}
+	return f.parseText(text)
+}
+
+// parseText, parses the text of a valid Float32, text equal to NullText is not
+// NULL.
+func (f *Float32) parseText(text []byte) error {
+	n, err := parseFloat(text, 32)
+	if err != nil {
+		f.Float32, f.Valid = 0, false
+		return newUnmarshalError("null.Float32", text, err)
+	}
+	f.Float32, f.Valid = float32(n), true
+	return nil
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface.
+func (s String) MarshalText() ([]byte, error) {
+	if s.Valid {
+		return []byte(s.String), nil
+	}
+	return []byte(NullText), nil
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface.
+func (s *String) UnmarshalText(text []byte) error {
+	if nullText(text) {
+		s.String, s.Valid = "", false
+		return nil
+	}
+	s.String, s.Valid = string(text), true
+	return nil
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface.
+func (s UnescapedString) MarshalText() ([]byte, error) {
+	return String(s).MarshalText()
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface.
+func (s *UnescapedString) UnmarshalText(text []byte) error {
+	return (*String)(s).UnmarshalText(text)
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface.
+func (b Bool) MarshalText() ([]byte, error) {
+	if b.Valid {
+		return strconv.AppendBool(make([]byte, 0, 5), b.Bool), nil
+	}
+	return []byte(NullText), nil
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface. Any
+// boolean accepted by strconv.ParseBool, such as "1" or "TRUE", is allowed.
+func (b *Bool) UnmarshalText(text []byte) error {
+	if nullText(text) {
+		b.Bool, b.Valid = false, false
+		return nil
+	}
+	return b.parseText(text)
+}
+
+// parseText, parses the text of a valid Bool, text equal to NullText is not
+// NULL.
+func (b *Bool) parseText(text []byte) error {
+	v, err := strconv.ParseBool(string(text))
+	if err != nil {
+		b.Bool, b.Valid = false, false
+		return newUnmarshalError("null.Bool", text, err)
+	}
+	b.Bool, b.Valid = v, true
+	return nil
+}
+
+// MarshalText, implements the encoding.TextMarshaler interface. The time is
+// formatted using the TimeFormat of DefaultEncoder, by default RFC 3339 with
+// sub-second precision added if present.
+func (t Time) MarshalText() ([]byte, error) {
+	if t.Valid {
+		b, valid, err := DefaultEncoder.formatTime(make([]byte, 0, len(time.RFC3339Nano)), t.Time, false)
+		if err != nil || valid {
+			return b, err
+		}
+	}
+	return []byte(NullText), nil
+}
+
+// UnmarshalText, implements the encoding.TextUnmarshaler interface. The time
+// is expected to be in one of the TimeFormats of DefaultDecoder, by default
+// RFC 3339.
+func (t *Time) UnmarshalText(text []byte) error {
+	if nullText(text) {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+	return t.parseText(text)
+}
+
+// parseText, parses the text of a valid Time, text equal to NullText is not
+// NULL.
+func (t *Time) parseText(text []byte) error {
+	var err error
+	t.Time, err = DefaultDecoder.parseTimeText(text)
+	t.Valid = (err == nil)
+	return unmarshalError("null.Time", text, err)
+}
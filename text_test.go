@@ -0,0 +1,203 @@
+package null
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+var textTests = []struct {
+	in  interface{}
+	out string
+}{
+	{NewInt(-123), "-123"},
+	{Int{}, ""},
+	{NewFloat64(1.5), "1.5"},
+	{NewFloat64(1e21), "1e+21"},
+	{Float64{}, ""},
+	{NewFloat32(0.1), "0.1"},
+	{Float32{}, ""},
+	{NewString("a b"), "a b"},
+	{String{}, ""},
+	{NewUnescapedString("<&>"), "<&>"},
+	{UnescapedString{}, ""},
+	{NewBool(true), "true"},
+	{NewBool(false), "false"},
+	{Bool{}, ""},
+	{NewTime(time.Date(2017, 10, 20, 23, 4, 56, 123000000, time.UTC)), "2017-10-20T23:04:56.123Z"},
+	{Time{}, ""},
+}
+
+func TestMarshalText(t *testing.T) {
+	for _, test := range textTests {
+		b, err := test.in.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText(%+v): %v", test.in, err)
+			continue
+		}
+		if string(b) != test.out {
+			t.Errorf("MarshalText(%+v) = %q, want %q", test.in, b, test.out)
+		}
+	}
+	if _, err := NewFloat64(math.NaN()).MarshalText(); err == nil {
+		t.Error("MarshalText(NaN): expected error")
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	for _, test := range textTests {
+		v := newTextUnmarshaler(test.in)
+		if err := v.UnmarshalText([]byte(test.out)); err != nil {
+			t.Errorf("%T: UnmarshalText(%q): %v", test.in, test.out, err)
+			continue
+		}
+		if !textEqual(v, test.in) {
+			t.Errorf("%T: UnmarshalText(%q) = %+v, want %+v", test.in, test.out, v, test.in)
+		}
+	}
+	for _, test := range []struct {
+		v   encoding.TextUnmarshaler
+		in  string
+		typ string
+		err error
+	}{
+		{new(Int), "1.5", "null.Int", ErrSyntax},
+		{new(Int), "x", "null.Int", ErrSyntax},
+		{new(Uint64), "-1", "null.Uint64", ErrSyntax},
+		{new(Float64), "1e1000", "null.Float64", ErrRange},
+		{new(Float32), "x", "null.Float32", ErrSyntax},
+		{new(Bool), "yes", "null.Bool", ErrSyntax},
+		{new(Time), "2017-10-20", "null.Time", ErrSyntax},
+	} {
+		err := test.v.UnmarshalText([]byte(test.in))
+		var uerr *UnmarshalError
+		if !errors.As(err, &uerr) || uerr.Type != test.typ || uerr.Value != test.in || !errors.Is(err, test.err) {
+			t.Errorf("%T: UnmarshalText(%q) = %v: want *UnmarshalError for %s wrapping %v",
+				test.v, test.in, err, test.typ, test.err)
+		}
+	}
+	var b Bool
+	if err := b.UnmarshalText([]byte("1")); err != nil || !b.Valid || !b.Bool {
+		t.Errorf("Bool: UnmarshalText(%q) = %+v, %v", "1", b, err)
+	}
+}
+
+// newTextUnmarshaler, returns a pointer to a new value of the type of v.
+func newTextUnmarshaler(v interface{}) encoding.TextUnmarshaler {
+	switch v.(type) {
+	case Int:
+		return new(Int)
+	case Uint64:
+		return new(Uint64)
+	case Float64:
+		return new(Float64)
+	case Float32:
+		return new(Float32)
+	case String:
+		return new(String)
+	case UnescapedString:
+		return new(UnescapedString)
+	case Bool:
+		return new(Bool)
+	case Time:
+		return new(Time)
+	}
+	panic("newTextUnmarshaler: unsupported type")
+}
+
+// textEqual, reports whether the pointer p points to a value equal to v.
+func textEqual(p encoding.TextUnmarshaler, v interface{}) bool {
+	switch p := p.(type) {
+	case *Time:
+		u := v.(Time)
+		return p.Valid == u.Valid && p.Time.Equal(u.Time)
+	case *Int:
+		return *p == v
+	case *Uint64:
+		return *p == v
+	case *Float64:
+		return *p == v
+	case *Float32:
+		return *p == v
+	case *String:
+		return *p == v
+	case *UnescapedString:
+		return *p == v
+	case *Bool:
+		return *p == v
+	}
+	return false
+}
+
+func TestNullText(t *testing.T) {
+	defer func(s string) { NullText = s }(NullText)
+	NullText = `\N`
+
+	if b, _ := (Int{}).MarshalText(); string(b) != `\N` {
+		t.Errorf("MarshalText(Int{}) = %q, want %q", b, `\N`)
+	}
+	s := NewString("x")
+	if err := s.UnmarshalText([]byte(`\N`)); err != nil || s.Valid {
+		t.Errorf("UnmarshalText(%q) = %+v, %v: want invalid String", `\N`, s, err)
+	}
+	if err := s.UnmarshalText([]byte("")); err != nil || s != NewString("") {
+		t.Errorf("UnmarshalText(%q) = %+v, %v: want valid empty String", "", s, err)
+	}
+}
+
+func TestTextTimeFormat(t *testing.T) {
+	defer func(e Encoder, d Decoder) {
+		DefaultEncoder, DefaultDecoder = e, d
+	}(DefaultEncoder, DefaultDecoder)
+
+	DefaultEncoder.TimeFormat = TimeFormatUnixMilli
+	DefaultDecoder.TimeFormats = []string{"2006-01-02", TimeFormatUnixMilli}
+
+	v := NewTime(time.Unix(1508540696, 123000000))
+	b, err := v.MarshalText()
+	if err != nil || string(b) != "1508540696123" {
+		t.Errorf("MarshalText(%v) = %s, %v want %s", v.Time, b, err, "1508540696123")
+	}
+	var u Time
+	if err := u.UnmarshalText(b); err != nil || !u.Time.Equal(v.Time) {
+		t.Errorf("UnmarshalText(%s) = %v, %v want %v", b, u.Time, err, v.Time)
+	}
+	if err := u.UnmarshalText([]byte("2017-10-20")); err != nil || !u.Valid {
+		t.Errorf("UnmarshalText(%s) = %+v, %v", "2017-10-20", u, err)
+	}
+
+	DefaultEncoder = Encoder{OutOfRange: InvalidTimeNull}
+	if b, err := NewTime(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)).MarshalText(); err != nil || len(b) != 0 {
+		t.Errorf("MarshalText: year 10000: got %q, %v want NullText", b, err)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	m := map[String]Int{NewString("a"): NewInt(1)}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"a":1}` {
+		t.Errorf("Marshal(%v) = %s, want %s", m, b, `{"a":1}`)
+	}
+	var n map[Int]Bool
+	if err := json.Unmarshal([]byte(`{"1":"true","2":null}`), &n); err != nil {
+		t.Fatal(err)
+	}
+	if len(n) != 2 || n[NewInt(1)] != NewBool(true) || n[NewInt(2)] != (Bool{}) {
+		t.Errorf("Unmarshal: got %v", n)
+	}
+}
+
+func TestUnmarshalTextFloat32(t *testing.T) {
+	for _, in := range []string{"0", "-1.5", "1e-10"} {
+		var f Float32
+		if err := f.UnmarshalText([]byte(in)); err != nil || !f.Valid {
+			t.Errorf("UnmarshalText(%q) = %+v, %v", in, f, err)
+		}
+	}
+}
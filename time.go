@@ -0,0 +1,248 @@
+package null
+
+import (
+	"strings"
+	"time"
+)
+
+// Time formats that encode a Time as a JSON number holding the time elapsed
+// since January 1, 1970 UTC, instead of as a JSON string. These may be used
+// in place of a time layout by Encoder.TimeFormat and Decoder.TimeFormats.
+//
+// Sub-unit precision is encoded as a decimal fraction, e.g. "1.5" seconds.
+// Times outside of the range representable by time.Time.UnixNano should not
+// be encoded with TimeFormatUnixNano.
+const (
+	TimeFormatUnix      = "unix"
+	TimeFormatUnixMilli = "unixmilli"
+	TimeFormatUnixMicro = "unixmicro"
+	TimeFormatUnixNano  = "unixnano"
+)
+
+// RFC3339Precision, returns a RFC 3339 time layout with exactly digits
+// fractional second digits, e.g. RFC3339Precision(3) returns
+// "2006-01-02T15:04:05.000Z07:00". Digits must be between 0 and 9.
+func RFC3339Precision(digits int) string {
+	if digits <= 0 {
+		return time.RFC3339
+	}
+	if digits > 9 {
+		digits = 9
+	}
+	return "2006-01-02T15:04:05." + strings.Repeat("0", digits) + "Z07:00"
+}
+
+// unixDigits, returns the number of fractional second digits of the whole
+// units of the Unix time format, or -1 if format is a time layout.
+func unixDigits(format string) int {
+	switch format {
+	case TimeFormatUnix:
+		return 0
+	case TimeFormatUnixMilli:
+		return 3
+	case TimeFormatUnixMicro:
+		return 6
+	case TimeFormatUnixNano:
+		return 9
+	}
+	return -1
+}
+
+// appendTime, appends the JSON encoding of time t to dst using the time format
+// of Encoder enc. By default times are encoded as a quoted RFC 3339 string,
+// matching time.Time.MarshalJSON.
+func (enc *Encoder) appendTime(dst []byte, t time.Time) ([]byte, error) {
+	dst, valid, err := enc.formatTime(dst, t, true)
+	if err == nil && !valid {
+		dst = append(dst, nullLiteral...)
+	}
+	return dst, err
+}
+
+// formatTime, appends time t to dst using the time format of Encoder enc,
+// time layouts are quoted if quote is set. If t cannot be represented and
+// the OutOfRange action of enc is InvalidTimeNull valid is false and dst is
+// returned unchanged.
+func (enc *Encoder) formatTime(dst []byte, t time.Time, quote bool) (_ []byte, valid bool, err error) {
+	layout := enc.TimeFormat
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+	if digits := unixDigits(layout); digits != -1 {
+		return appendUnix(dst, t, digits), true, nil
+	}
+	if strings.HasPrefix(layout, "2006-01-02T15:04:05") {
+		if y := t.Year(); y < 0 || y >= 10000 {
+			// RFC 3339 is clear that years are 4 digits exactly.
+			// See golang.org/issue/4556#c15 for more discussion.
+			switch enc.OutOfRange {
+			case InvalidTimeNull:
+				return dst, false, nil
+			case InvalidTimeZero:
+				t = time.Time{}
+			default:
+				return dst, false, &TimeError{Value: t.String(), Err: ErrYearRange}
+			}
+		}
+	}
+	if quote {
+		dst = append(dst, '"')
+	}
+	dst = t.AppendFormat(dst, layout)
+	if quote {
+		dst = append(dst, '"')
+	}
+	return dst, true, nil
+}
+
+var pow10tab = [...]int64{1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9}
+
+// appendUnix, appends the time elapsed since the Unix epoch in units of
+// 10^-digits seconds to dst, with any remainder as a decimal fraction.
+func appendUnix(dst []byte, t time.Time, digits int) []byte {
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	scale := pow10tab[9-digits]
+	whole := sec*pow10tab[digits] + nsec/scale
+	frac := nsec % scale
+	if whole < 0 && frac > 0 {
+		// The fraction is positive, but the number is negative.
+		whole++
+		frac = scale - frac
+	}
+	if whole < 0 || (whole == 0 && sec < 0) {
+		dst = append(dst, '-')
+		whole = -whole
+	}
+	dst = appendUint(dst, uint64(whole))
+	if frac == 0 {
+		return dst
+	}
+	dst = append(dst, '.')
+	for scale /= 10; scale > 0 && frac > 0; scale /= 10 {
+		dst = append(dst, byte('0'+frac/scale))
+		frac %= scale
+	}
+	return dst
+}
+
+// appendUint, appends the decimal representation of n to dst.
+func appendUint(dst []byte, n uint64) []byte {
+	var a [20]byte
+	i := len(a)
+	for n >= 10 {
+		i--
+		a[i] = byte('0' + n%10)
+		n /= 10
+	}
+	i--
+	a[i] = byte('0' + n)
+	return append(dst, a[i:]...)
+}
+
+// parseTime, parses the JSON time data using the time formats of Decoder
+// dec, which are tried in order. The error of the first format is returned
+// if none match.
+func (dec *Decoder) parseTime(data []byte) (time.Time, error) {
+	var t time.Time
+	if len(dec.TimeFormats) == 0 {
+		err := t.UnmarshalJSON(data)
+		return t, err
+	}
+	if dec.Mode == DecodeStrict {
+		return dec.parseTimeFormats(data, parseTimeFormatStrict)
+	}
+	return dec.parseTimeFormats(data, parseTimeFormat)
+}
+
+// parseTimeText, parses the time text using the time formats of Decoder dec,
+// see parseTime.
+func (dec *Decoder) parseTimeText(text []byte) (time.Time, error) {
+	var t time.Time
+	if len(dec.TimeFormats) == 0 {
+		err := t.UnmarshalText(text)
+		return t, err
+	}
+	return dec.parseTimeFormats(text, parseTimeTextFormat)
+}
+
+func (dec *Decoder) parseTimeFormats(data []byte, parse func([]byte, string) (time.Time, error)) (time.Time, error) {
+	var first error
+	for _, format := range dec.TimeFormats {
+		t, err := parse(data, format)
+		if err == nil {
+			return t, nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return time.Time{}, first
+}
+
+func parseTimeFormat(data []byte, format string) (time.Time, error) {
+	if digits := unixDigits(format); digits != -1 {
+		return parseUnix(unquote(data), digits)
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return time.Time{}, ErrUnsupportedType
+	}
+	return time.Parse(format, string(data[1:len(data)-1]))
+}
+
+// parseTimeFormatStrict, is parseTimeFormat but does not allow quoted Unix
+// times.
+func parseTimeFormatStrict(data []byte, format string) (time.Time, error) {
+	if unixDigits(format) != -1 && isJSONString(data) {
+		return time.Time{}, ErrUnsupportedType
+	}
+	return parseTimeFormat(data, format)
+}
+
+func parseTimeTextFormat(text []byte, format string) (time.Time, error) {
+	if digits := unixDigits(format); digits != -1 {
+		return parseUnix(text, digits)
+	}
+	return time.Parse(format, string(text))
+}
+
+// parseUnix, parses the decimal number s holding the time elapsed since the
+// Unix epoch in units of 10^-digits seconds.
+func parseUnix(s []byte, digits int) (time.Time, error) {
+	num := s
+	neg := len(num) > 0 && num[0] == '-'
+	if neg {
+		num = num[1:]
+	}
+	whole, frac := num, num[len(num):]
+	for i, c := range num {
+		if c == '.' {
+			whole, frac = num[:i], num[i+1:]
+			if len(frac) == 0 {
+				return time.Time{}, ErrSyntax
+			}
+			break
+		}
+	}
+	n, err := atoui(whole, 64)
+	if err != nil || n > maxInt64 {
+		return time.Time{}, ErrSyntax
+	}
+	// Convert the fraction to nanoseconds, truncating digits beyond
+	// nanosecond precision.
+	var nsec int64
+	scale := pow10tab[9-digits]
+	for _, c := range frac {
+		if c < '0' || c > '9' {
+			return time.Time{}, ErrSyntax
+		}
+		scale /= 10
+		nsec += int64(c-'0') * scale
+	}
+	unit := pow10tab[digits]
+	sec := int64(n) / unit
+	nsec += (int64(n) % unit) * pow10tab[9-digits]
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return time.Unix(sec, nsec), nil
+}
@@ -0,0 +1,175 @@
+package null
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var timeFormatTests = []struct {
+	format string
+	t      time.Time
+	out    string
+}{
+	{"", time.Date(2017, 10, 20, 23, 4, 56, 123456000, time.UTC), `"2017-10-20T23:04:56.123456Z"`},
+	{time.RFC3339, time.Date(2017, 10, 20, 23, 4, 56, 123456000, time.UTC), `"2017-10-20T23:04:56Z"`},
+	{RFC3339Precision(3), time.Date(2017, 10, 20, 23, 4, 56, 120000000, time.UTC), `"2017-10-20T23:04:56.120Z"`},
+	{RFC3339Precision(6), time.Date(2017, 10, 20, 23, 4, 56, 0, time.FixedZone("", -7*3600)), `"2017-10-20T23:04:56.000000-07:00"`},
+	{"2006-01-02 15:04:05", time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC), `"2017-10-20 23:04:56"`},
+	{TimeFormatUnix, time.Unix(1508540696, 0), `1508540696`},
+	{TimeFormatUnix, time.Unix(1508540696, 500000000), `1508540696.5`},
+	{TimeFormatUnix, time.Unix(0, 0), `0`},
+	{TimeFormatUnix, time.Unix(-2, 500000000), `-1.5`},
+	{TimeFormatUnix, time.Unix(-1, 500000000), `-0.5`},
+	{TimeFormatUnix, time.Unix(-1, 0), `-1`},
+	{TimeFormatUnixMilli, time.Unix(1508540696, 123000000), `1508540696123`},
+	{TimeFormatUnixMilli, time.Unix(1508540696, 123456789), `1508540696123.456789`},
+	{TimeFormatUnixMilli, time.Unix(-1, 999999999), `-0.000001`},
+	{TimeFormatUnixMicro, time.Unix(1508540696, 123456000), `1508540696123456`},
+	{TimeFormatUnixMicro, time.Unix(1508540696, 123456700), `1508540696123456.7`},
+	{TimeFormatUnixNano, time.Unix(1508540696, 123456789), `1508540696123456789`},
+	{TimeFormatUnixNano, time.Unix(-1508540696, 123456789), `-1508540695876543211`},
+}
+
+func TestEncoderTimeFormat(t *testing.T) {
+	for _, test := range timeFormatTests {
+		e := Encoder{TimeFormat: test.format}
+		b, err := e.MarshalTime(NewTime(test.t))
+		if err != nil {
+			t.Errorf("%q: MarshalTime(%v): %v", test.format, test.t, err)
+			continue
+		}
+		if string(b) != test.out {
+			t.Errorf("%q: MarshalTime(%v) = %s, want %s", test.format, test.t, b, test.out)
+		}
+	}
+}
+
+func TestDecoderTimeFormats(t *testing.T) {
+	for _, test := range timeFormatTests {
+		d := Decoder{TimeFormats: []string{test.format}}
+		if test.format == "" {
+			d.TimeFormats = nil
+		}
+		var v Time
+		if err := d.UnmarshalTime([]byte(test.out), &v); err != nil {
+			t.Errorf("%q: UnmarshalTime(%s): %v", test.format, test.out, err)
+			continue
+		}
+		want := test.t
+		switch test.format {
+		case time.RFC3339, RFC3339Precision(3):
+			want = want.Truncate(time.Second)
+		}
+		if test.format == RFC3339Precision(3) {
+			want = want.Add(120 * time.Millisecond)
+		}
+		if !v.Valid || !v.Time.Equal(want) {
+			t.Errorf("%q: UnmarshalTime(%s) = %v, want %v", test.format, test.out, v.Time, want)
+		}
+	}
+}
+
+func TestDecoderTimeFormatsOrder(t *testing.T) {
+	d := Decoder{TimeFormats: []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+		TimeFormatUnixMilli,
+	}}
+	want := time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC)
+	for _, in := range []string{
+		`"2017-10-20T23:04:56Z"`,
+		`"2017-10-20 23:04:56"`,
+		`1508540696000`,
+		`"1508540696000"`,
+	} {
+		var v Time
+		if err := d.UnmarshalTime([]byte(in), &v); err != nil {
+			t.Errorf("UnmarshalTime(%s): %v", in, err)
+			continue
+		}
+		if !v.Valid || !v.Time.Equal(want) {
+			t.Errorf("UnmarshalTime(%s) = %v, want %v", in, v.Time, want)
+		}
+	}
+	for _, in := range []string{`"2017/10/20"`, `true`, `1.`, `-`, `1e3`, `"1x"`} {
+		v := NewTime(want)
+		if err := d.UnmarshalTime([]byte(in), &v); err == nil {
+			t.Errorf("UnmarshalTime(%s): expected error", in)
+		}
+		if v.Valid {
+			t.Errorf("UnmarshalTime(%s): expected invalid Time", in)
+		}
+	}
+}
+
+func TestDefaultTimeFormat(t *testing.T) {
+	defer func(e Encoder, d Decoder) {
+		DefaultEncoder, DefaultDecoder = e, d
+	}(DefaultEncoder, DefaultDecoder)
+
+	DefaultEncoder.TimeFormat = TimeFormatUnixMilli
+	DefaultDecoder.TimeFormats = []string{TimeFormatUnixMilli}
+
+	v := NewTime(time.Unix(1508540696, 123000000))
+	b, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "1508540696123" {
+		t.Errorf("MarshalJSON(%v) = %s, want %s", v.Time, b, "1508540696123")
+	}
+	var u Time
+	if err := u.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if !u.Valid || !u.Time.Equal(v.Time) {
+		t.Errorf("UnmarshalJSON(%s) = %v, want %v", b, u.Time, v.Time)
+	}
+}
+
+func TestRFC3339Precision(t *testing.T) {
+	tests := map[int]string{
+		-1: time.RFC3339,
+		0:  time.RFC3339,
+		1:  "2006-01-02T15:04:05.0Z07:00",
+		9:  "2006-01-02T15:04:05.000000000Z07:00",
+		10: "2006-01-02T15:04:05.000000000Z07:00",
+	}
+	for digits, want := range tests {
+		if got := RFC3339Precision(digits); got != want {
+			t.Errorf("RFC3339Precision(%d) = %q, want %q", digits, got, want)
+		}
+	}
+}
+
+func TestEncoderOutOfRange(t *testing.T) {
+	v := NewTime(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		enc Encoder
+		out string
+		err error
+	}{
+		{Encoder{}, "", ErrYearRange},
+		{Encoder{OutOfRange: InvalidTimeError}, "", ErrYearRange},
+		{Encoder{OutOfRange: InvalidTimeNull}, "null", nil},
+		{Encoder{OutOfRange: InvalidTimeZero}, `"0001-01-01T00:00:00Z"`, nil},
+		{Encoder{OutOfRange: InvalidTimeZero, TimeFormat: "2006-01-02"}, `"10000-01-01"`, nil},
+		{Encoder{TimeFormat: TimeFormatUnix}, "253402300800", nil},
+	}
+	for _, test := range tests {
+		b, err := test.enc.MarshalTime(v)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%+v: MarshalTime(%v) = %s, %v want error %v", test.enc, v.Time, b, err, test.err)
+			}
+			continue
+		}
+		if err != nil || string(b) != test.out {
+			t.Errorf("%+v: MarshalTime(%v) = %s, %v want %s", test.enc, v.Time, b, err, test.out)
+		}
+	}
+	if _, err := NewTime(time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC)).MarshalJSON(); !errors.Is(err, ErrYearRange) {
+		t.Errorf("MarshalJSON: year -1: got error %v, want %v", err, ErrYearRange)
+	}
+}
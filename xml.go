@@ -0,0 +1,310 @@
+package null
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// xsiNamespace, is the XML Schema instance namespace of the xsi:nil attribute.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// marshalXML, encodes an element with the text of a valid value, or an empty
+// element with the attribute xsi:nil="true" if the value is NULL.
+func marshalXML(e *xml.Encoder, start xml.StartElement, text []byte, valid bool) error {
+	if !valid {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// marshalXMLAttr, returns an attribute with the text of a valid value, or the
+// zero Attr, which is omitted, if the value is NULL.
+func marshalXMLAttr(name xml.Name, text []byte, valid bool) (xml.Attr, error) {
+	if !valid {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// unmarshalXML, decodes the text of the element start, isNil is true if the
+// element has the attribute xsi:nil="true".
+func unmarshalXML(d *xml.Decoder, start xml.StartElement) (text string, isNil bool, err error) {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") {
+			if attr.Value == "true" || attr.Value == "1" {
+				return "", true, d.Skip()
+			}
+		}
+	}
+	err = d.DecodeElement(&text, &start)
+	return text, false, err
+}
+
+// MarshalXML, implements the xml.Marshaler interface. NULL is encoded as an
+// empty element with the attribute xsi:nil="true".
+func (i Int) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	b, err := i.MarshalText()
+	if err != nil {
+		return err
+	}
+	return marshalXML(e, start, b, i.Valid)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface.
+func (i *Int) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, isNil, err := unmarshalXML(d, start)
+	if err != nil || isNil {
+		i.Int, i.Valid = 0, false
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface. NULL values
+// are omitted.
+func (i Int) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	b, err := i.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttr(name, b, i.Valid)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (i *Int) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML, implements the xml.Marshaler interface. NULL is encoded as an
+// empty element with the attribute xsi:nil="true".
+func (u Uint64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	b, err := u.MarshalText()
+	if err != nil {
+		return err
+	}
+	return marshalXML(e, start, b, u.Valid)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface.
+func (u *Uint64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, isNil, err := unmarshalXML(d, start)
+	if err != nil || isNil {
+		u.Uint64, u.Valid = 0, false
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface. NULL values
+// are omitted.
+func (u Uint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	b, err := u.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttr(name, b, u.Valid)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (u *Uint64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML, implements the xml.Marshaler interface. NULL is encoded as an
+// empty element with the attribute xsi:nil="true".
+func (f Float64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	b, err := f.MarshalText()
+	if err != nil {
+		return err
+	}
+	return marshalXML(e, start, b, f.Valid)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface.
+func (f *Float64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, isNil, err := unmarshalXML(d, start)
+	if err != nil || isNil {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+	return f.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface. NULL values
+// are omitted.
+func (f Float64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	b, err := f.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttr(name, b, f.Valid)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (f *Float64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return f.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML, implements the xml.Marshaler interface. NULL is encoded as an
+// empty element with the attribute xsi:nil="true".
+func (f Float32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	b, err := f.MarshalText()
+	if err != nil {
+		return err
+	}
+	return marshalXML(e, start, b, f.Valid)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface.
+func (f *Float32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, isNil, err := unmarshalXML(d, start)
+	if err != nil || isNil {
+		f.Float32, f.Valid = 0, false
+		return err
+	}
+	return f.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface. NULL values
+// are omitted.
+func (f Float32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	b, err := f.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttr(name, b, f.Valid)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (f *Float32) UnmarshalXMLAttr(attr xml.Attr) error {
+	return f.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML, implements the xml.Marshaler interface. NULL is encoded as an
+// empty element with the attribute xsi:nil="true".
+func (s String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, []byte(s.String), s.Valid)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface. Unlike
+// UnmarshalText, the text of an element is never treated as NULL.
+func (s *String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, isNil, err := unmarshalXML(d, start)
+	if err != nil || isNil {
+		s.String, s.Valid = "", false
+		return err
+	}
+	s.String, s.Valid = text, true
+	return nil
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface. NULL values
+// are omitted.
+func (s String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, []byte(s.String), s.Valid)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (s *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	s.String, s.Valid = attr.Value, true
+	return nil
+}
+
+// MarshalXML, implements the xml.Marshaler interface.
+func (s UnescapedString) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return String(s).MarshalXML(e, start)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface.
+func (s *UnescapedString) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return (*String)(s).UnmarshalXML(d, start)
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface.
+func (s UnescapedString) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return String(s).MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (s *UnescapedString) UnmarshalXMLAttr(attr xml.Attr) error {
+	return (*String)(s).UnmarshalXMLAttr(attr)
+}
+
+// MarshalXML, implements the xml.Marshaler interface. NULL is encoded as an
+// empty element with the attribute xsi:nil="true".
+func (b Bool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, _ := b.MarshalText()
+	return marshalXML(e, start, text, b.Valid)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface.
+func (b *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, isNil, err := unmarshalXML(d, start)
+	if err != nil || isNil {
+		b.Bool, b.Valid = false, false
+		return err
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface. NULL values
+// are omitted.
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, _ := b.MarshalText()
+	return marshalXMLAttr(name, text, b.Valid)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.UnmarshalText([]byte(attr.Value))
+}
+
+// xmlText, returns the text of Time t, valid is false if t is NULL.
+func (t Time) xmlText() (text []byte, valid bool, err error) {
+	if !t.Valid {
+		return nil, false, nil
+	}
+	return DefaultEncoder.formatTime(make([]byte, 0, len(time.RFC3339Nano)), t.Time, false)
+}
+
+// MarshalXML, implements the xml.Marshaler interface. NULL is encoded as an
+// empty element with the attribute xsi:nil="true".
+func (t Time) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	b, valid, err := t.xmlText()
+	if err != nil {
+		return err
+	}
+	return marshalXML(e, start, b, valid)
+}
+
+// UnmarshalXML, implements the xml.Unmarshaler interface.
+func (t *Time) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, isNil, err := unmarshalXML(d, start)
+	if err != nil || isNil {
+		t.Time, t.Valid = time.Time{}, false
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr, implements the xml.MarshalerAttr interface. NULL values
+// are omitted.
+func (t Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	b, valid, err := t.xmlText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return marshalXMLAttr(name, b, valid)
+}
+
+// UnmarshalXMLAttr, implements the xml.UnmarshalerAttr interface.
+func (t *Time) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.UnmarshalText([]byte(attr.Value))
+}
@@ -0,0 +1,107 @@
+package null
+
+import (
+	"encoding/xml"
+	"math"
+	"testing"
+	"time"
+)
+
+type xmlRecord struct {
+	XMLName xml.Name `xml:"record"`
+	ID      Int      `xml:"id,attr"`
+	Kind    String   `xml:"kind,attr"`
+	Int     Int      `xml:"int"`
+	Uint64  Uint64   `xml:"uint64"`
+	Float64 Float64  `xml:"float64"`
+	Float32 Float32  `xml:"float32"`
+	String  String   `xml:"string"`
+	Bool    Bool     `xml:"bool"`
+	Time    Time     `xml:"time"`
+}
+
+func TestMarshalXML(t *testing.T) {
+	tests := []struct {
+		in  xmlRecord
+		out string
+	}{
+		{
+			xmlRecord{
+				ID:      NewInt(1),
+				Kind:    NewString("a&b"),
+				Int:     NewInt(-2),
+				Uint64:  NewUint64(math.MaxUint64),
+				Float64: NewFloat64(1.5),
+				Float32: NewFloat32(0.1),
+				String:  NewString("<x>"),
+				Bool:    NewBool(true),
+				Time:    NewTime(time.Date(2017, 10, 20, 23, 4, 56, 0, time.UTC)),
+			},
+			`<record id="1" kind="a&amp;b"><int>-2</int><uint64>18446744073709551615</uint64>` +
+				`<float64>1.5</float64>` +
+				`<float32>0.1</float32><string>&lt;x&gt;</string><bool>true</bool>` +
+				`<time>2017-10-20T23:04:56Z</time></record>`,
+		},
+		{
+			xmlRecord{String: NewString("")},
+			`<record>` +
+				`<int xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></int>` +
+				`<uint64 xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></uint64>` +
+				`<float64 xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></float64>` +
+				`<float32 xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></float32>` +
+				`<string></string>` +
+				`<bool xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></bool>` +
+				`<time xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></time>` +
+				`</record>`,
+		},
+	}
+	for _, test := range tests {
+		b, err := xml.Marshal(test.in)
+		if err != nil {
+			t.Errorf("Marshal(%+v): %v", test.in, err)
+			continue
+		}
+		if string(b) != test.out {
+			t.Errorf("Marshal(%+v):\ngot:  %s\nwant: %s", test.in, b, test.out)
+		}
+		var v xmlRecord
+		if err := xml.Unmarshal(b, &v); err != nil {
+			t.Errorf("Unmarshal(%s): %v", b, err)
+			continue
+		}
+		v.XMLName = test.in.XMLName
+		if v != test.in {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", b, v, test.in)
+		}
+	}
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	const in = `<record xmlns:i="http://www.w3.org/2001/XMLSchema-instance" id="7">` +
+		`<int i:nil="true"/><uint64 xsi:nil="true"/><float64 xsi:nil="1"/><string>NULL</string>` +
+		`<bool>1</bool><time></time></record>`
+	v := xmlRecord{
+		Int:     NewInt(1),
+		Uint64:  NewUint64(1),
+		Float64: NewFloat64(1),
+		Time:    NewTime(time.Now()),
+	}
+	if err := xml.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	want := xmlRecord{
+		XMLName: v.XMLName,
+		ID:      NewInt(7),
+		String:  NewString("NULL"),
+		Bool:    NewBool(true),
+	}
+	if v != want {
+		t.Errorf("Unmarshal(%s) = %+v, want %+v", in, v, want)
+	}
+	if err := xml.Unmarshal([]byte(`<record><int>x</int></record>`), &v); err == nil {
+		t.Error("Unmarshal: expected error")
+	}
+	if err := xml.Unmarshal([]byte(`<record><uint64>-1</uint64></record>`), &v); err == nil {
+		t.Error("Unmarshal: expected error for negative Uint64")
+	}
+}